@@ -0,0 +1,147 @@
+package html
+
+import (
+	"iter"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/terawatthour/html/atom"
+)
+
+// AnchorOptions configures HeadingAnchors.
+type AnchorOptions struct {
+	// Levels lists the heading levels given ids, all levels when empty.
+	Levels []int
+	// Link makes the stage append an `<a href="#id">` to the content of every heading with an id it handles.
+	Link bool
+	// LinkClass is the class of the injected links, if any.
+	LinkClass string
+	// LinkText is the text of the injected links, if any.
+	LinkText string
+}
+
+// HeadingAnchors returns a stage giving headings without an id one made from their text by Slugify. Slugs are made
+// unique by appending `-1`, `-2`, ... when the same id was already used earlier in the document. Ids appearing only
+// later in the stream cannot be taken into account. Headings are held back until their end tag, so that their text
+// is known.
+func HeadingAnchors(opts AnchorOptions) Stage {
+	return func(tokens iter.Seq[Token]) iter.Seq[Token] {
+		return func(yield func(Token) bool) {
+			used := map[string]bool{}
+			var heading []Token
+
+			emit := func(tokens ...Token) bool {
+				for _, token := range tokens {
+					if !yield(token) {
+						return false
+					}
+				}
+				return true
+			}
+
+			for token := range tokens {
+				if heading != nil {
+					heading = append(heading, token)
+					end, ok := token.(*EndTag)
+					if !ok || headingLevel(strings.ToLower(end.Name)) == 0 {
+						continue
+					}
+					if !emit(anchorHeading(heading, used, opts)...) {
+						return
+					}
+					heading = nil
+					continue
+				}
+
+				if tag, ok := token.(*StartTag); ok {
+					if id := tag.ID(); id != "" {
+						used[id] = true
+					}
+					level := headingLevel(strings.ToLower(tag.Name))
+					if level != 0 && !tag.IsSelfClosing && (len(opts.Levels) == 0 || slices.Contains(opts.Levels, level)) {
+						heading = []Token{token}
+						continue
+					}
+				}
+
+				if !yield(token) {
+					return
+				}
+			}
+			emit(heading...)
+		}
+	}
+}
+
+// anchorHeading gives the heading an id if it lacks one and injects the link before its end tag.
+func anchorHeading(heading []Token, used map[string]bool, opts AnchorOptions) []Token {
+	start, end := heading[0].(*StartTag), heading[len(heading)-1].(*EndTag)
+
+	id := start.ID()
+	if id == "" {
+		var text strings.Builder
+		for _, token := range heading {
+			if t, ok := token.(*Text); ok {
+				text.WriteString(t.Value)
+			}
+		}
+
+		slug := Slugify(text.String())
+		id = slug
+		for n := 1; used[id]; n++ {
+			id = slug + "-" + strconv.Itoa(n)
+		}
+		used[id] = true
+
+		tag := *start
+		tag.Attributes = make(map[string]Attribute, len(start.Attributes)+1)
+		for name, attribute := range start.Attributes {
+			tag.Attributes[name] = attribute
+		}
+		tag.Attributes["id"] = Attribute{Name: "id", Atom: atom.Id, Value: id}
+		heading[0] = &tag
+	}
+
+	if !opts.Link {
+		return heading
+	}
+
+	link := &StartTag{Name: "a", Atom: atom.A, Attributes: map[string]Attribute{
+		"href": {Name: "href", Atom: atom.Href, Value: "#" + id},
+	}, Location: end.Location}
+	if opts.LinkClass != "" {
+		link.Attributes["class"] = Attribute{Name: "class", Atom: atom.Class, Value: opts.LinkClass}
+	}
+
+	injected := []Token{link}
+	if opts.LinkText != "" {
+		injected = append(injected, &Text{opts.LinkText, end.Location})
+	}
+	injected = append(injected, &EndTag{Name: "a", Atom: atom.A, Location: end.Location})
+	return slices.Insert(heading, len(heading)-1, injected...)
+}
+
+// Slugify turns text into a URL-safe fragment: lowercase letters and digits, with runs of anything else replaced by
+// single hyphens. Text without letters or digits gives "section".
+func Slugify(text string) string {
+	var b strings.Builder
+	hyphen := false
+	for _, c := range strings.ToLower(text) {
+		if unicode.IsLetter(c) || unicode.IsDigit(c) {
+			if hyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(c)
+			hyphen = false
+		} else if c != '\'' && c != '’' {
+			hyphen = true
+		}
+	}
+
+	if b.Len() == 0 {
+		return "section"
+	}
+	return b.String()
+}
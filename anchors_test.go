@@ -0,0 +1,55 @@
+package html
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSlugify(t *testing.T) {
+	tests := map[string]string{
+		"Getting Started":             "getting-started",
+		"  What's new in v2.0?  ":     "whats-new-in-v2-0",
+		"Ünïcode — Überschrift":       "ünïcode-überschrift",
+		"!!!":                         "section",
+		"API & <code>Tokenize</code>": "api-code-tokenize-code",
+	}
+	for text, expected := range tests {
+		if got := Slugify(text); got != expected {
+			t.Errorf("%q: got %q, expected %q", text, got, expected)
+		}
+	}
+}
+
+func TestHeadingAnchors(t *testing.T) {
+	template := `<h1>Guide</h1><h2 id="intro">Intro</h2><h2>Setup <em>steps</em></h2><h2>Setup steps</h2><h3>Deep</h3><h2>Intro</h2>`
+
+	var got []string
+	for token := range Pipe(Tokenize(template), HeadingAnchors(AnchorOptions{Levels: []int{2}, Link: true, LinkClass: "anchor", LinkText: "#"})) {
+		s := token.(fmt.Stringer).String()
+		got = append(got, s[:strings.LastIndex(s, " @")])
+	}
+
+	expected := []string{
+		`<h1>`, `"Guide"`, `</h1>`,
+		`<h2 id="intro">`, `"Intro"`, `<a class="anchor" href="#intro">`, `"#"`, `</a>`, `</h2>`,
+		`<h2 id="setup-steps">`, `"Setup "`, `<em>`, `"steps"`, `</em>`, `<a class="anchor" href="#setup-steps">`, `"#"`, `</a>`, `</h2>`,
+		`<h2 id="setup-steps-1">`, `"Setup steps"`, `<a class="anchor" href="#setup-steps-1">`, `"#"`, `</a>`, `</h2>`,
+		`<h3>`, `"Deep"`, `</h3>`,
+		`<h2 id="intro-1">`, `"Intro"`, `<a class="anchor" href="#intro-1">`, `"#"`, `</a>`, `</h2>`,
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %q, expected %q", got, expected)
+	}
+}
+
+func TestHeadingAnchorsUnterminated(t *testing.T) {
+	var got []Token
+	for token := range Pipe(Tokenize("<h1>Title"), HeadingAnchors(AnchorOptions{})) {
+		got = append(got, token)
+	}
+	if len(got) != 2 || got[0].(*StartTag).ID() != "" {
+		t.Errorf("expected the unterminated heading unchanged, got %v", got)
+	}
+}
@@ -0,0 +1,37 @@
+// Package atom interns common HTML tag and attribute names as integers, so that they can be compared and
+// switched on without string comparisons. Element and attribute names share a single table, e.g. the atom of
+// the `title` element is the same as that of the `title` attribute.
+package atom
+
+// Atom is an interned lowercase name. The zero Atom means the name is not in the table.
+type Atom uint32
+
+var table = func() map[string]Atom {
+	table := make(map[string]Atom, len(names))
+	for a, name := range names {
+		if name != "" {
+			table[name] = Atom(a)
+		}
+	}
+	return table
+}()
+
+func (a Atom) String() string {
+	if int(a) < len(names) {
+		return names[a]
+	}
+	return ""
+}
+
+// Lookup returns the atom of the lowercase name s, or 0 if s is not a known name.
+func Lookup(s []byte) Atom {
+	return table[string(s)]
+}
+
+// String returns the interned string of s when s is a known name, avoiding an allocation, or a copy of s otherwise.
+func String(s []byte) string {
+	if a := Lookup(s); a != 0 {
+		return a.String()
+	}
+	return string(s)
+}
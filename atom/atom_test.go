@@ -0,0 +1,22 @@
+package atom
+
+import "testing"
+
+func TestLookup(t *testing.T) {
+	for a := Atom(1); int(a) < len(names); a++ {
+		if got := Lookup([]byte(a.String())); got != a {
+			t.Errorf("Lookup(%q) = %d, expected %d", a.String(), got, a)
+		}
+	}
+
+	if Lookup([]byte("DIV")) != 0 || Lookup([]byte("custom-element")) != 0 || Atom(0).String() != "" {
+		t.Error("unknown names must not be interned")
+	}
+	if HttpEquiv.String() != "http-equiv" || String([]byte("div")) != "div" || String([]byte("x-y")) != "x-y" {
+		t.Error("unexpected atom strings")
+	}
+
+	if allocs := testing.AllocsPerRun(100, func() { _ = String([]byte("blockquote")) }); allocs != 0 {
+		t.Errorf("expected interned lookups not to allocate, got %v allocations", allocs)
+	}
+}
@@ -0,0 +1,453 @@
+package atom
+
+const (
+	A Atom = iota + 1
+	Abbr
+	Accept
+	AcceptCharset
+	Accesskey
+	Action
+	Address
+	Allow
+	Alt
+	Area
+	Article
+	Aside
+	Async
+	Audio
+	Autocapitalize
+	Autocomplete
+	Autofocus
+	Autoplay
+	B
+	Base
+	Bdi
+	Bdo
+	Blockquote
+	Body
+	Br
+	Button
+	Canvas
+	Caption
+	Charset
+	Checked
+	Cite
+	Class
+	Code
+	Col
+	Colgroup
+	Cols
+	Colspan
+	Content
+	Contenteditable
+	Controls
+	Coords
+	Crossorigin
+	Data
+	Datalist
+	Datetime
+	Dd
+	Decoding
+	Default
+	Defer
+	Del
+	Details
+	Dfn
+	Dialog
+	Dir
+	Dirname
+	Disabled
+	Div
+	Dl
+	Download
+	Draggable
+	Dt
+	Em
+	Embed
+	Enctype
+	Enterkeyhint
+	Fieldset
+	Figcaption
+	Figure
+	Footer
+	For
+	Form
+	Formaction
+	H1
+	H2
+	H3
+	H4
+	H5
+	H6
+	Head
+	Header
+	Headers
+	Height
+	Hgroup
+	Hidden
+	High
+	Hr
+	Href
+	Hreflang
+	Html
+	HttpEquiv
+	I
+	Id
+	Iframe
+	Img
+	Inert
+	Input
+	Inputmode
+	Ins
+	Integrity
+	Is
+	Itemid
+	Itemprop
+	Itemref
+	Itemscope
+	Itemtype
+	Kbd
+	Kind
+	Label
+	Lang
+	Legend
+	Li
+	Link
+	List
+	Loading
+	Loop
+	Low
+	Main
+	Map
+	Mark
+	Math
+	Max
+	Maxlength
+	Media
+	Menu
+	Meta
+	Meter
+	Method
+	Min
+	Minlength
+	Multiple
+	Muted
+	Name
+	Nav
+	Nomodule
+	Nonce
+	Noscript
+	Novalidate
+	Object
+	Ol
+	Open
+	Optgroup
+	Optimum
+	Option
+	Output
+	P
+	Pattern
+	Picture
+	Ping
+	Placeholder
+	Playsinline
+	Popover
+	Poster
+	Pre
+	Preload
+	Progress
+	Property
+	Q
+	Readonly
+	Referrerpolicy
+	Rel
+	Required
+	Reversed
+	Role
+	Rows
+	Rowspan
+	Rp
+	Rt
+	Ruby
+	S
+	Samp
+	Sandbox
+	Scope
+	Script
+	Search
+	Section
+	Select
+	Selected
+	Shape
+	Size
+	Sizes
+	Slot
+	Small
+	Source
+	Span
+	Spellcheck
+	Src
+	Srcdoc
+	Srclang
+	Srcset
+	Start
+	Step
+	Strong
+	Style
+	Sub
+	Summary
+	Sup
+	Svg
+	Tabindex
+	Table
+	Target
+	Tbody
+	Td
+	Template
+	Textarea
+	Tfoot
+	Th
+	Thead
+	Time
+	Title
+	Tr
+	Track
+	Translate
+	Type
+	U
+	Ul
+	Usemap
+	Value
+	Var
+	Video
+	Wbr
+	Width
+	Wrap
+	Xmlns
+)
+
+var names = [...]string{
+	A:               "a",
+	Abbr:            "abbr",
+	Accept:          "accept",
+	AcceptCharset:   "accept-charset",
+	Accesskey:       "accesskey",
+	Action:          "action",
+	Address:         "address",
+	Allow:           "allow",
+	Alt:             "alt",
+	Area:            "area",
+	Article:         "article",
+	Aside:           "aside",
+	Async:           "async",
+	Audio:           "audio",
+	Autocapitalize:  "autocapitalize",
+	Autocomplete:    "autocomplete",
+	Autofocus:       "autofocus",
+	Autoplay:        "autoplay",
+	B:               "b",
+	Base:            "base",
+	Bdi:             "bdi",
+	Bdo:             "bdo",
+	Blockquote:      "blockquote",
+	Body:            "body",
+	Br:              "br",
+	Button:          "button",
+	Canvas:          "canvas",
+	Caption:         "caption",
+	Charset:         "charset",
+	Checked:         "checked",
+	Cite:            "cite",
+	Class:           "class",
+	Code:            "code",
+	Col:             "col",
+	Colgroup:        "colgroup",
+	Cols:            "cols",
+	Colspan:         "colspan",
+	Content:         "content",
+	Contenteditable: "contenteditable",
+	Controls:        "controls",
+	Coords:          "coords",
+	Crossorigin:     "crossorigin",
+	Data:            "data",
+	Datalist:        "datalist",
+	Datetime:        "datetime",
+	Dd:              "dd",
+	Decoding:        "decoding",
+	Default:         "default",
+	Defer:           "defer",
+	Del:             "del",
+	Details:         "details",
+	Dfn:             "dfn",
+	Dialog:          "dialog",
+	Dir:             "dir",
+	Dirname:         "dirname",
+	Disabled:        "disabled",
+	Div:             "div",
+	Dl:              "dl",
+	Download:        "download",
+	Draggable:       "draggable",
+	Dt:              "dt",
+	Em:              "em",
+	Embed:           "embed",
+	Enctype:         "enctype",
+	Enterkeyhint:    "enterkeyhint",
+	Fieldset:        "fieldset",
+	Figcaption:      "figcaption",
+	Figure:          "figure",
+	Footer:          "footer",
+	For:             "for",
+	Form:            "form",
+	Formaction:      "formaction",
+	H1:              "h1",
+	H2:              "h2",
+	H3:              "h3",
+	H4:              "h4",
+	H5:              "h5",
+	H6:              "h6",
+	Head:            "head",
+	Header:          "header",
+	Headers:         "headers",
+	Height:          "height",
+	Hgroup:          "hgroup",
+	Hidden:          "hidden",
+	High:            "high",
+	Hr:              "hr",
+	Href:            "href",
+	Hreflang:        "hreflang",
+	Html:            "html",
+	HttpEquiv:       "http-equiv",
+	I:               "i",
+	Id:              "id",
+	Iframe:          "iframe",
+	Img:             "img",
+	Inert:           "inert",
+	Input:           "input",
+	Inputmode:       "inputmode",
+	Ins:             "ins",
+	Integrity:       "integrity",
+	Is:              "is",
+	Itemid:          "itemid",
+	Itemprop:        "itemprop",
+	Itemref:         "itemref",
+	Itemscope:       "itemscope",
+	Itemtype:        "itemtype",
+	Kbd:             "kbd",
+	Kind:            "kind",
+	Label:           "label",
+	Lang:            "lang",
+	Legend:          "legend",
+	Li:              "li",
+	Link:            "link",
+	List:            "list",
+	Loading:         "loading",
+	Loop:            "loop",
+	Low:             "low",
+	Main:            "main",
+	Map:             "map",
+	Mark:            "mark",
+	Math:            "math",
+	Max:             "max",
+	Maxlength:       "maxlength",
+	Media:           "media",
+	Menu:            "menu",
+	Meta:            "meta",
+	Meter:           "meter",
+	Method:          "method",
+	Min:             "min",
+	Minlength:       "minlength",
+	Multiple:        "multiple",
+	Muted:           "muted",
+	Name:            "name",
+	Nav:             "nav",
+	Nomodule:        "nomodule",
+	Nonce:           "nonce",
+	Noscript:        "noscript",
+	Novalidate:      "novalidate",
+	Object:          "object",
+	Ol:              "ol",
+	Open:            "open",
+	Optgroup:        "optgroup",
+	Optimum:         "optimum",
+	Option:          "option",
+	Output:          "output",
+	P:               "p",
+	Pattern:         "pattern",
+	Picture:         "picture",
+	Ping:            "ping",
+	Placeholder:     "placeholder",
+	Playsinline:     "playsinline",
+	Popover:         "popover",
+	Poster:          "poster",
+	Pre:             "pre",
+	Preload:         "preload",
+	Progress:        "progress",
+	Property:        "property",
+	Q:               "q",
+	Readonly:        "readonly",
+	Referrerpolicy:  "referrerpolicy",
+	Rel:             "rel",
+	Required:        "required",
+	Reversed:        "reversed",
+	Role:            "role",
+	Rows:            "rows",
+	Rowspan:         "rowspan",
+	Rp:              "rp",
+	Rt:              "rt",
+	Ruby:            "ruby",
+	S:               "s",
+	Samp:            "samp",
+	Sandbox:         "sandbox",
+	Scope:           "scope",
+	Script:          "script",
+	Search:          "search",
+	Section:         "section",
+	Select:          "select",
+	Selected:        "selected",
+	Shape:           "shape",
+	Size:            "size",
+	Sizes:           "sizes",
+	Slot:            "slot",
+	Small:           "small",
+	Source:          "source",
+	Span:            "span",
+	Spellcheck:      "spellcheck",
+	Src:             "src",
+	Srcdoc:          "srcdoc",
+	Srclang:         "srclang",
+	Srcset:          "srcset",
+	Start:           "start",
+	Step:            "step",
+	Strong:          "strong",
+	Style:           "style",
+	Sub:             "sub",
+	Summary:         "summary",
+	Sup:             "sup",
+	Svg:             "svg",
+	Tabindex:        "tabindex",
+	Table:           "table",
+	Target:          "target",
+	Tbody:           "tbody",
+	Td:              "td",
+	Template:        "template",
+	Textarea:        "textarea",
+	Tfoot:           "tfoot",
+	Th:              "th",
+	Thead:           "thead",
+	Time:            "time",
+	Title:           "title",
+	Tr:              "tr",
+	Track:           "track",
+	Translate:       "translate",
+	Type:            "type",
+	U:               "u",
+	Ul:              "ul",
+	Usemap:          "usemap",
+	Value:           "value",
+	Var:             "var",
+	Video:           "video",
+	Wbr:             "wbr",
+	Width:           "width",
+	Wrap:            "wrap",
+	Xmlns:           "xmlns",
+}
@@ -0,0 +1,71 @@
+package html
+
+import "strings"
+
+// AutoClose returns the end tag to insert after the start tag ending right before the rune offset cursor, or an
+// empty string when there is no such start tag, it is void or self-closing, or the element is already closed. An
+// element counts as closed when an end tag matches it and no element of the same name opened before it is left
+// unclosed, which would otherwise own that end tag. Illegal input is skipped, since the template is being edited.
+func AutoClose(template string, cursor int) string {
+	type open struct {
+		name  string
+		index int
+	}
+
+	var (
+		stack    []open
+		unclosed []int
+		names    []string
+		target   = -1
+	)
+
+	t := NewTokenizer(template)
+	for {
+		start := t.i
+		token := t.next()
+		if token.Kind() == "EOF" || (token.Kind() == "ILLEGAL" && t.i == start) {
+			break
+		}
+
+		switch token := token.(type) {
+		case *StartTag:
+			if t.i == cursor {
+				if token.IsSelfClosing || isVoidElement(token.Name) {
+					return ""
+				}
+				target = len(names)
+			}
+			if !token.IsSelfClosing && !isVoidElement(token.Name) {
+				stack = append(stack, open{strings.ToLower(token.Name), len(names)})
+				names = append(names, token.Name)
+			}
+		case *EndTag:
+			name := strings.ToLower(token.Name)
+			for i := len(stack) - 1; i >= 0; i-- {
+				if stack[i].name != name {
+					continue
+				}
+				for _, o := range stack[i+1:] {
+					unclosed = append(unclosed, o.index)
+				}
+				stack = stack[:i]
+				break
+			}
+		}
+	}
+
+	if target < 0 {
+		return ""
+	}
+	for _, o := range stack {
+		unclosed = append(unclosed, o.index)
+	}
+
+	name := strings.ToLower(names[target])
+	for _, index := range unclosed {
+		if index == target || (index < target && strings.ToLower(names[index]) == name) {
+			return "</" + names[target] + ">"
+		}
+	}
+	return ""
+}
@@ -0,0 +1,34 @@
+package html
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAutoClose(t *testing.T) {
+	tests := []struct {
+		template string
+		expected string
+	}{
+		{"<div>|", "</div>"},
+		{"<Section class=\"a\">|", "</Section>"},
+		{"<div>|</div>", ""},
+		{"<ul><li>|</ul>", "</li>"},
+		{"<div><div>|</div>", "</div>"},
+		{"<div><p>|</p></div>", ""},
+		{"<br>|", ""},
+		{"<img src=\"a.png\"/>|", ""},
+		{"<custom-element/>|", ""},
+		{"<script>|", "</script>"},
+		{"<p>text|", ""},
+		{"<p <div>|", "</div>"},
+	}
+
+	for _, test := range tests {
+		cursor := len([]rune(test.template[:strings.Index(test.template, "|")]))
+		template := strings.Replace(test.template, "|", "", 1)
+		if got := AutoClose(template, cursor); got != test.expected {
+			t.Errorf("%q: got %q, expected %q", test.template, got, test.expected)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package html
+
+import (
+	"iter"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// BaseURL returns the base URL of a document: the href of its first `<base>` element having one, resolved against
+// fallback, which may be nil. Documents without such an element, or whose base does not resolve or uses the data
+// or javascript scheme, keep fallback as their base URL.
+func BaseURL(tokens iter.Seq[Token], fallback *url.URL) *url.URL {
+	for token := range tokens {
+		if tag, ok := token.(*StartTag); ok {
+			if base, ok := frozenBaseURL(tag, fallback); ok {
+				return base
+			}
+		}
+	}
+	return fallback
+}
+
+// BaseURL returns the base URL of the document, see the package-level BaseURL.
+func (d *Document) BaseURL(fallback *url.URL) *url.URL {
+	return BaseURL(slices.Values(d.Tokens()), fallback)
+}
+
+// frozenBaseURL returns the base URL set by a `<base href>` tag, ok being false for any other tag.
+func frozenBaseURL(tag *StartTag, fallback *url.URL) (base *url.URL, ok bool) {
+	if !strings.EqualFold(tag.Name, "base") {
+		return nil, false
+	}
+	href, ok := tag.GetAttribute("href")
+	if !ok {
+		return nil, false
+	}
+
+	resolved, err := ResolveURL(fallback, href.Value)
+	if err != nil || resolved.Scheme == "data" || resolved.Scheme == "javascript" {
+		return fallback, true
+	}
+	return resolved, true
+}
+
+// ResolveURL parses an attribute value as a URL reference resolved against base, which may be nil. Like browsers,
+// it ignores leading and trailing whitespace and any tabs and newlines within the value.
+func ResolveURL(base *url.URL, reference string) (*url.URL, error) {
+	reference = strings.TrimFunc(reference, isWhitespace)
+	reference = strings.Map(func(c rune) rune {
+		if c == '\t' || c == '\n' || c == '\r' {
+			return -1
+		}
+		return c
+	}, reference)
+
+	if base == nil {
+		return url.Parse(reference)
+	}
+	return base.Parse(reference)
+}
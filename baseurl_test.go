@@ -0,0 +1,45 @@
+package html
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestBaseURL(t *testing.T) {
+	fallback, _ := url.Parse("https://example.com/a/page.html")
+
+	tests := []struct {
+		template string
+		expected string
+	}{
+		{`<p>no base</p>`, "https://example.com/a/page.html"},
+		{`<base target="_blank"><base href="/docs/"><base href="/other/">`, "https://example.com/docs/"},
+		{"<base href=\" https://cdn.example.com/x/\n \">", "https://cdn.example.com/x/"},
+		{`<base href="javascript:alert(1)">`, "https://example.com/a/page.html"},
+		{`<base href="data:text/html,x">`, "https://example.com/a/page.html"},
+		{`<base href="http://[::1">`, "https://example.com/a/page.html"},
+	}
+
+	for _, test := range tests {
+		if got := BaseURL(Tokenize(test.template), fallback); got.String() != test.expected {
+			t.Errorf("%q: got %s, expected %s", test.template, got, test.expected)
+		}
+	}
+
+	tokenizer := NewTokenizer(`<base href="sub/">`)
+	if got := tokenizer.Document().BaseURL(fallback); got.String() != "https://example.com/a/sub/" {
+		t.Errorf("unexpected document base URL %s", got)
+	}
+}
+
+func TestResolveURL(t *testing.T) {
+	base, _ := url.Parse("https://example.com/a/")
+
+	resolved, err := ResolveURL(base, "  b/\tc\n.html ")
+	if err != nil || resolved.String() != "https://example.com/a/b/c.html" {
+		t.Errorf("got %v, %v", resolved, err)
+	}
+	if resolved, err := ResolveURL(nil, "/relative"); err != nil || resolved.String() != "/relative" {
+		t.Errorf("got %v, %v", resolved, err)
+	}
+}
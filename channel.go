@@ -0,0 +1,45 @@
+package html
+
+import (
+	"context"
+	"io"
+)
+
+// TokenizeChan tokenizes the input read from r in a goroutine, sending the tokens, excluding EOF, on the returned
+// channel with the given buffer size before closing it. Tokens are sent as soon as the input read so far completes
+// them, as with Stream. Sending blocks until the consumer catches up, and stops once ctx is done. A read error is
+// sent as an Illegal token located at the start of input, after the tokens completed before it.
+func TokenizeChan(ctx context.Context, r io.Reader, buf int) <-chan Token {
+	tokens := make(chan Token, buf)
+
+	go func() {
+		defer close(tokens)
+
+		send := func(token Token) bool {
+			select {
+			case tokens <- token:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		tokenizer := NewTokenizer("")
+		stream := tokenizer.Stream(func(token Token) error {
+			if !send(token) {
+				return ctx.Err()
+			}
+			return nil
+		})
+
+		if _, err := io.Copy(stream, r); err != nil {
+			if ctx.Err() == nil {
+				send(&Illegal{Reason: "reading input: " + err.Error(), Location: Location{Line: 1, Column: 1}, Code: CodeReadError})
+			}
+			return
+		}
+		stream.Close()
+	}()
+
+	return tokens
+}
@@ -0,0 +1,86 @@
+package html
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+)
+
+func TestTokenizeChan(t *testing.T) {
+	template := "<p>one</p><p>two</p>"
+
+	var expected []string
+	for token := range Tokenize(template) {
+		expected = append(expected, token.Kind())
+	}
+
+	var got []string
+	for token := range TokenizeChan(context.Background(), strings.NewReader(template), 2) {
+		got = append(got, token.Kind())
+	}
+
+	if strings.Join(got, " ") != strings.Join(expected, " ") {
+		t.Errorf("got %v, expected %v", got, expected)
+	}
+}
+
+func TestTokenizeChanCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	tokens := TokenizeChan(ctx, strings.NewReader(strings.Repeat("<p>text</p>", 1000)), 0)
+
+	<-tokens
+	cancel()
+
+	count := 0
+	for range tokens {
+		count++
+	}
+	if count > 1 {
+		t.Errorf("expected tokenization to stop after cancellation, got %d more tokens", count)
+	}
+}
+
+func TestTokenizeChanReadError(t *testing.T) {
+	tokens := TokenizeChan(context.Background(), iotest.ErrReader(errors.New("broken")), 0)
+
+	token := <-tokens
+	if illegal, ok := token.(*Illegal); !ok || illegal.Reason != "reading input: broken" {
+		t.Errorf("unexpected token %v", token)
+	}
+	if _, ok := <-tokens; ok {
+		t.Error("expected the channel to be closed")
+	}
+}
+
+func TestTokenizeChanIncremental(t *testing.T) {
+	r, w := io.Pipe()
+	defer w.Close()
+	tokens := TokenizeChan(context.Background(), r, 0)
+
+	go w.Write([]byte("<p>one</p>"))
+
+	var got []string
+	for range 3 {
+		select {
+		case token := <-tokens:
+			got = append(got, token.Kind())
+		case <-time.After(time.Second):
+			t.Fatalf("got %v before the input ended, expected three tokens", got)
+		}
+	}
+}
+
+func TestTokenizeChanReadErrorAfterTokens(t *testing.T) {
+	tokens := TokenizeChan(context.Background(), io.MultiReader(strings.NewReader("<p>"), iotest.ErrReader(errors.New("broken"))), 0)
+
+	if token, ok := (<-tokens).(*StartTag); !ok || token.Name != "p" {
+		t.Errorf("unexpected token %v", token)
+	}
+	if token, ok := (<-tokens).(*Illegal); !ok || token.Code != CodeReadError {
+		t.Errorf("unexpected token %v", token)
+	}
+}
@@ -0,0 +1,67 @@
+// Command htmltok prints the tokens of an HTML template, one per line, in the golden dump format or as JSON.
+//
+// Usage:
+//
+//	htmltok [-json] [-expressions] [file]
+//
+// The template is read from standard input when no file is given.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/terawatthour/html"
+)
+
+func main() {
+	asJSON := flag.Bool("json", false, "print tokens as JSON, one per line")
+	expressions := flag.Bool("expressions", false, "recognize {{ }} expressions")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: htmltok [-json] [-expressions] [file]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if err := run(flag.Arg(0), *asJSON, *expressions); err != nil {
+		fmt.Fprintln(os.Stderr, "htmltok:", err)
+		os.Exit(1)
+	}
+}
+
+func run(path string, asJSON, expressions bool) error {
+	input := io.Reader(os.Stdin)
+	if path != "" {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		input = file
+	}
+
+	template, err := io.ReadAll(input)
+	if err != nil {
+		return err
+	}
+
+	tokenizer := html.NewTokenizer(string(template))
+	if expressions {
+		tokenizer.EnableExpressions(html.DefaultDelimiters)
+	}
+
+	if !asJSON {
+		return html.Dump(os.Stdout, tokenizer.All())
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	for token := range tokenizer.All() {
+		if err := encoder.Encode(token); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package html
+
+// Codes identifying illegal tokens, stable across releases unlike their reasons, so that tools can suppress or
+// document specific errors. They are also the codes of the diagnostics reported for them.
+const (
+	// CodeInvalidDoctype is reported for a DOCTYPE not naming `html`.
+	CodeInvalidDoctype = "invalid-doctype"
+	// CodeMalformedDoctype is reported for a DOCTYPE with anything but a legacy compat string after its name.
+	CodeMalformedDoctype = "malformed-doctype"
+	// CodeMarkupDeclaration is reported for comments and other markup declarations, which are not supported.
+	CodeMarkupDeclaration = "markup-declaration"
+	// CodeMissingTagName is reported for `<` or `</` followed by something other than a letter in a tag.
+	CodeMissingTagName = "missing-tag-name"
+	// CodeInvalidTagName is reported for tag names containing characters other than letters, digits, `-` and `:`.
+	CodeInvalidTagName = "invalid-tag-name"
+	// CodeInvalidAttributeName is reported for attribute names containing characters other than letters, digits,
+	// `-`, `_` and `:`.
+	CodeInvalidAttributeName = "invalid-attribute-name"
+	// CodeUnquotedAttributeValue is reported for attribute values without quotes, which are not supported.
+	CodeUnquotedAttributeValue = "unquoted-attribute-value"
+	// CodeUnterminatedAttributeValue is reported for attribute values missing their closing quote.
+	CodeUnterminatedAttributeValue = "unterminated-attribute-value"
+	// CodeMissingClosingBracket is reported for tags not ending with `>`.
+	CodeMissingClosingBracket = "missing-closing-bracket"
+	// CodeEOFInTag is reported for input ending within a tag.
+	CodeEOFInTag = "eof-in-tag"
+	// CodeUnterminatedExpression is reported for expressions missing their right delimiter.
+	CodeUnterminatedExpression = "unterminated-expression"
+	// CodeUnterminatedExpressionString is reported for string literals in expressions missing their closing quote.
+	CodeUnterminatedExpressionString = "unterminated-expression-string"
+	// CodeReadError is reported by TokenizeChan for input which could not be read.
+	CodeReadError = "read-error"
+	// CodeIntegrity is reported by InjectIntegrity for subresources whose integrity could not be resolved.
+	CodeIntegrity = "integrity"
+)
@@ -0,0 +1,75 @@
+package html
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestIllegalCodes(t *testing.T) {
+	tests := map[string]string{
+		"<!DOCTYPE xml>":    CodeInvalidDoctype,
+		"<!DOCTYPE html x>": CodeMalformedDoctype,
+		"<!-- comment -->":  CodeMarkupDeclaration,
+		"</1>":              CodeMissingTagName,
+		"<di*v>":            CodeInvalidTagName,
+		"<div a*b>":         CodeInvalidAttributeName,
+		"<div id=x>":        CodeUnquotedAttributeValue,
+		`<div id="x>`:       CodeUnterminatedAttributeValue,
+		"<div id='x'/ >":    CodeMissingClosingBracket,
+		"<div id":           CodeEOFInTag,
+		"{{ name":           CodeUnterminatedExpression,
+		`{{ "name }}`:       CodeUnterminatedExpressionString,
+		"<div {{ a>":        CodeUnterminatedExpression,
+	}
+
+	for template, expected := range tests {
+		tokenizer := NewTokenizer(template)
+		tokenizer.EnableExpressions(DefaultDelimiters)
+
+		var illegal *Illegal
+		for token := range tokenizer.All() {
+			if token, ok := token.(*Illegal); ok {
+				illegal = token
+				break
+			}
+		}
+		if illegal == nil || illegal.Code != expected {
+			t.Errorf("%q: expected code %s, got %v", template, expected, illegal)
+		}
+	}
+}
+
+func TestIllegalCodeDiagnostic(t *testing.T) {
+	if code := (&Illegal{Reason: "custom"}).Diagnostic().Code; code != "illegal" {
+		t.Errorf("expected tokens without a code to give illegal, got %q", code)
+	}
+	if code := (&Illegal{Reason: "custom", Code: CodeEOFInTag}).Diagnostic().Code; code != CodeEOFInTag {
+		t.Errorf("expected the code of the token, got %q", code)
+	}
+}
+
+func TestDumpIllegalCodes(t *testing.T) {
+	dump := "1:1 ILLEGAL markup-declaration \"comments and markup declarations are not supported\"\n1:17 ILLEGAL \"custom\"\n"
+
+	tokens, err := ParseDump(strings.NewReader(dump))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []Token{
+		&Illegal{Reason: "comments and markup declarations are not supported", Location: Location{Line: 1, Column: 1}, Code: CodeMarkupDeclaration},
+		&Illegal{Reason: "custom", Location: Location{Line: 1, Column: 17}},
+	}
+	if !reflect.DeepEqual(tokens, expected) {
+		t.Errorf("got %v, expected %v", tokens, expected)
+	}
+
+	var b strings.Builder
+	if err := Dump(&b, slices.Values(tokens)); err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != dump {
+		t.Errorf("got\n%s\nexpected\n%s", b.String(), dump)
+	}
+}
@@ -0,0 +1,82 @@
+package html
+
+import (
+	"strconv"
+	"strings"
+)
+
+// CSSPath returns a selector uniquely identifying the start tag among the tokens of its document, e.g.
+// `#main > ul:nth-child(2) > li:nth-child(3)`, which CompileSelector accepts. The path climbs from the tag to the
+// nearest element with an id unique in the document, or up to the top of the document, naming other elements by
+// their position among the children of their parent. A path climbing to the top starts with an element marked
+// `:root`, e.g. `html:root:nth-child(1) > body:nth-child(2)`. Elements are nested as the tokens suggest, so an end
+// tag closes the innermost open element of the same name along with the elements opened after it. The path is empty
+// if the tag is not among the tokens.
+func CSSPath(tokens []Token, target *StartTag) string {
+	ids := map[string]int{}
+	for _, token := range tokens {
+		if tag, ok := token.(*StartTag); ok {
+			if id := tag.ID(); id != "" {
+				ids[id]++
+			}
+		}
+	}
+
+	type frame struct {
+		tag      *StartTag
+		index    int
+		children int
+	}
+	open := []frame{{}}
+
+	for _, token := range tokens {
+		switch token := token.(type) {
+		case *StartTag:
+			parent := &open[len(open)-1]
+			parent.children++
+			current := frame{tag: token, index: parent.children}
+
+			if token == target {
+				var path []string
+				for depth, element := range append(open[1:], current) {
+					if id := element.tag.ID(); ids[id] == 1 && isIdentifier(id) {
+						path = []string{"#" + id}
+						continue
+					}
+					step := strings.ToLower(element.tag.Name)
+					if depth == 0 {
+						step += ":root"
+					}
+					path = append(path, step+":nth-child("+strconv.Itoa(element.index)+")")
+				}
+				return strings.Join(path, " > ")
+			}
+
+			if !token.IsSelfClosing && !isVoidElement(strings.ToLower(token.Name)) {
+				open = append(open, current)
+			}
+		case *EndTag:
+			for i := len(open) - 1; i > 0; i-- {
+				if strings.EqualFold(open[i].tag.Name, token.Name) {
+					open = open[:i]
+					break
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// isIdentifier reports whether the value can be written as a CSS identifier without escapes.
+// https://www.w3.org/TR/css-syntax-3/#would-start-an-identifier
+func isIdentifier(value string) bool {
+	if value == "" || !isIdentifierStart(rune(value[0])) && (value[0] != '-' || len(value) < 2 || isDigit(rune(value[1]))) {
+		return false
+	}
+	for _, c := range value {
+		if !isIdentifierCharacter(c) {
+			return false
+		}
+	}
+	return true
+}
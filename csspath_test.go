@@ -0,0 +1,75 @@
+package html
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCSSPath(t *testing.T) {
+	template := `<html><body><div id="main"><ul><li>a</li><li>b<br><em>c</em></li></ul></div>
+<p id="dup"><span>x</span></p><p id="dup"><span>y</span></p><p id="1st"><b>z</b></p></body></html>`
+	tokens := slices.Collect(Tokenize(template))
+
+	expected := map[string]string{
+		"main": "#main",
+		"em":   "#main > ul:nth-child(1) > li:nth-child(2) > em:nth-child(2)",
+		"y":    "html:root:nth-child(1) > body:nth-child(1) > p:nth-child(3) > span:nth-child(1)",
+		"z":    "html:root:nth-child(1) > body:nth-child(1) > p:nth-child(4) > b:nth-child(1)",
+	}
+
+	got := map[string]string{}
+	for i, token := range tokens {
+		tag, ok := token.(*StartTag)
+		if !ok {
+			continue
+		}
+		switch {
+		case tag.ID() == "main":
+			got["main"] = CSSPath(tokens, tag)
+		case tag.Name == "em":
+			got["em"] = CSSPath(tokens, tag)
+		case i+1 < len(tokens):
+			if text, ok := tokens[i+1].(*Text); ok && (text.Value == "y" || text.Value == "z") {
+				got[text.Value] = CSSPath(tokens, tag)
+			}
+		}
+	}
+
+	for key, path := range expected {
+		if got[key] != path {
+			t.Errorf("%s: got %q, expected %q", key, got[key], path)
+		}
+	}
+	if path := CSSPath(tokens, &StartTag{Name: "p"}); path != "" {
+		t.Errorf("expected no path for a tag from elsewhere, got %q", path)
+	}
+}
+
+func TestCSSPathTopLevel(t *testing.T) {
+	tokens := slices.Collect(Tokenize(`<section><p></p><div></div></section><div></div>`))
+	if path := CSSPath(tokens, tokens[len(tokens)-2].(*StartTag)); path != "div:root:nth-child(2)" {
+		t.Errorf("expected the path of a top-level element to be anchored, got %q", path)
+	}
+}
+
+func TestCSSPathSelects(t *testing.T) {
+	template := `<html><body><div id="main"><ul><li>a</li><li>b<br><em>c</em></li></ul></div>
+<p id="dup"><span>x</span></p><p id="dup"><span>y</span></p></body></html><div><p></p></div>`
+	tokens := slices.Collect(Tokenize(template))
+
+	for _, token := range tokens {
+		tag, ok := token.(*StartTag)
+		if !ok {
+			continue
+		}
+		path := CSSPath(tokens, tag)
+		selector, err := CompileSelector(path)
+		if err != nil {
+			t.Errorf("%s: %v", path, err)
+			continue
+		}
+		if selected := slices.Collect(Select(slices.Values(tokens), selector)); len(selected) != 1 || selected[0] != tag {
+			t.Errorf("%s: expected to select only %v, got %v", path, tag, selected)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package html
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Diagnostic is a problem found in a template, shared by everything in the package which reports problems with
+// locations.
+type Diagnostic struct {
+	// Filename is the name of the template's file, if known.
+	Filename string
+	Range
+	// Severity is one of "error", "warning", "information" or "hint".
+	Severity string
+	// Code identifies the kind of problem, e.g. one of the Code constants.
+	Code    string
+	Message string
+	// Fix holds the edits which resolve the problem, if known.
+	Fix []TextEdit
+}
+
+// Error formats the diagnostic as `file:line:column: message`, the way compilers do, leaving out the file when it
+// is not known.
+func (d Diagnostic) Error() string {
+	if d.Filename == "" {
+		return fmt.Sprintf("%d:%d: %s", d.Start.Line, d.Start.Column, d.Message)
+	}
+	return fmt.Sprintf("%s:%d:%d: %s", d.Filename, d.Start.Line, d.Start.Column, d.Message)
+}
+
+// DiagnosticList is a list of diagnostics which is itself an error, formatted one diagnostic per line.
+type DiagnosticList []Diagnostic
+
+func (l DiagnosticList) Error() string {
+	lines := make([]string, len(l))
+	for i, diagnostic := range l {
+		lines[i] = diagnostic.Error()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Err returns the list as an error, or nil when it is empty.
+func (l DiagnosticList) Err() error {
+	if len(l) == 0 {
+		return nil
+	}
+	return l
+}
+
+// WithFilename attributes an error returned by the package to a file. Illegal tokens, diagnostics and diagnostic
+// lists become diagnostics with the filename set, any other error is prefixed with it.
+func WithFilename(filename string, err error) error {
+	var illegal *Illegal
+	var diagnostic Diagnostic
+	var list DiagnosticList
+
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &list):
+		named := make(DiagnosticList, len(list))
+		for i, diagnostic := range list {
+			diagnostic.Filename = filename
+			named[i] = diagnostic
+		}
+		return named
+	case errors.As(err, &diagnostic):
+		diagnostic.Filename = filename
+		return diagnostic
+	case errors.As(err, &illegal):
+		diagnostic = illegal.Diagnostic()
+		diagnostic.Filename = filename
+		return diagnostic
+	}
+	return fmt.Errorf("%s: %w", filename, err)
+}
+
+// TextEdit replaces the source within Range with NewText.
+type TextEdit struct {
+	Range
+	NewText string
+}
+
+// Diagnostic returns the illegal token as an error diagnostic with an empty range at its location. Its code is that
+// of the token, or "illegal" for tokens without one.
+func (t *Illegal) Diagnostic() Diagnostic {
+	code := t.Code
+	if code == "" {
+		code = "illegal"
+	}
+	return Diagnostic{
+		Range:    Range{t.Location, t.Location},
+		Severity: "error",
+		Code:     code,
+		Message:  t.Reason,
+	}
+}
+
+// Diagnostics tokenizes the remaining input and returns a diagnostic for every illegal token, ranging from where the
+// token became illegal to where tokenization resumes. Unquoted attribute values come with a fix quoting them.
+func (t *Tokenizer) Diagnostics() DiagnosticList {
+	return t.diagnose(false)
+}
+
+// diagnose reports illegal tokens, along with the problems found by Lint when lint is set.
+func (t *Tokenizer) diagnose(lint bool) DiagnosticList {
+	var diagnostics DiagnosticList
+	var open []openElement
+	var raw RawToken
+	for {
+		start := t.i
+		t.NextInto(&raw)
+
+		switch raw.Kind {
+		case "EOF":
+			if lint {
+				diagnostics = append(diagnostics, t.unclosed(open, raw.Location)...)
+			}
+			return diagnostics
+		case "ILLEGAL":
+			diagnostic := t.token(&raw).(*Illegal).Diagnostic()
+			diagnostic.Filename, diagnostic.End = t.filename, t.location()
+			if raw.Code == CodeUnquotedAttributeValue {
+				diagnostic.Fix = t.quoteFix(raw.Location)
+			}
+			diagnostics = append(diagnostics, diagnostic)
+			if t.i == start {
+				return diagnostics
+			}
+		case "START_TAG":
+			if lint {
+				diagnostics = append(diagnostics, t.lintStartTag(&raw)...)
+				name := string(raw.Name)
+				if !raw.IsSelfClosing && !isVoidElement(strings.ToLower(name)) {
+					open = append(open, openElement{name, raw.Location})
+				}
+			}
+		case "END_TAG":
+			for i := len(open) - 1; lint && i >= 0; i-- {
+				if strings.EqualFold(open[i].name, string(raw.Name)) {
+					diagnostics = append(diagnostics, t.unclosed(open[i+1:], raw.Location)...)
+					open = open[:i]
+					break
+				}
+			}
+		}
+	}
+}
+
+// SortDiagnostics orders diagnostics by their start, keeping the order of diagnostics starting at the same location.
+func SortDiagnostics(diagnostics []Diagnostic) {
+	slices.SortStableFunc(diagnostics, func(a, b Diagnostic) int { return a.Start.Cursor - b.Start.Cursor })
+}
@@ -0,0 +1,61 @@
+package html
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDiagnostics(t *testing.T) {
+	tokenizer := NewTokenizer("<p>ok</p>\n<div class=x>text</div><!-- comment -->")
+	diagnostics := tokenizer.Diagnostics()
+
+	if len(diagnostics) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %d: %v", len(diagnostics), diagnostics)
+	}
+
+	first := diagnostics[0]
+	if first.Severity != "error" || first.Code != CodeUnquotedAttributeValue || first.Message != "expected quotes in attribute definition" {
+		t.Errorf("unexpected diagnostic %+v", first)
+	}
+	if first.Start != (Location{2, 12, 21}) || first.End != first.Start {
+		t.Errorf("unexpected range %+v", first.Range)
+	}
+	if first.Error() != "2:12: expected quotes in attribute definition" {
+		t.Errorf("unexpected error string %q", first.Error())
+	}
+
+	second := diagnostics[1]
+	if second.Code != CodeMarkupDeclaration || second.Message != "comments and markup declarations are not supported" {
+		t.Errorf("unexpected diagnostic %+v", second)
+	}
+	if second.Start.Cursor != 33 || second.End.Cursor != 49 {
+		t.Errorf("expected the diagnostic to span the comment, got %+v", second.Range)
+	}
+}
+
+func TestDiagnosticFilenames(t *testing.T) {
+	tokenizer := NewTokenizer("<p id=x>\n<div title='a>")
+	tokenizer.SetFilename("views/index.html")
+
+	err := tokenizer.Diagnostics().Err()
+	expected := "views/index.html:1:7: expected quotes in attribute definition\n" +
+		"views/index.html:2:15: expected closing quote"
+	if err == nil || err.Error() != expected {
+		t.Errorf("got %v, expected %q", err, expected)
+	}
+
+	if err := (DiagnosticList{}).Err(); err != nil {
+		t.Errorf("expected no error for an empty list, got %v", err)
+	}
+
+	_, err = ToText(Tokenize("<p id=x>"))
+	if err := WithFilename("a.html", err); err == nil || err.Error() != "a.html:1:7: expected quotes in attribute definition" {
+		t.Errorf("unexpected error %v", err)
+	}
+	if err := WithFilename("a.html", errors.New("not found")); err.Error() != "a.html: not found" {
+		t.Errorf("unexpected error %v", err)
+	}
+	if err := WithFilename("a.html", nil); err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+}
@@ -0,0 +1,190 @@
+package html
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"iter"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Dump writes tokens in a stable, line-oriented format meant for golden files, one token per line:
+//
+//	1:1 DOCTYPE system
+//	1:16 START_TAG div class="card" hidden {{" attrs "}} /
+//	2:3 TEXT "Hello\n"
+//	3:1 END_TAG div
+//	3:7 EXPRESSION " name "
+//	3:19 ILLEGAL missing-tag-name "expected tag name"
+//
+// Each line starts with the line and column of the token. Attributes are sorted by name, with empty values written
+// as a bare name, and expressions in place of attributes follow them. Illegal tokens have their code, if any, before
+// their reason. Strings are quoted as Go string literals.
+// Cursors, attribute locations and expressions embedded in attribute values are not part of the format.
+func Dump(w io.Writer, tokens iter.Seq[Token]) error {
+	b := bufio.NewWriter(w)
+	for token := range tokens {
+		var location Location
+		var fields string
+
+		switch token := token.(type) {
+		case *Doctype:
+			location = token.Location
+			if token.HasSystem {
+				fields = " system"
+			}
+		case *StartTag:
+			location = token.Location
+			fields = " " + token.Name + dumpAttributes(token)
+		case *EndTag:
+			location, fields = token.Location, " "+token.Name
+		case *Text:
+			location, fields = token.Location, " "+strconv.Quote(token.Value)
+		case *Expression:
+			location, fields = token.Location, " "+strconv.Quote(token.Value)
+		case *Illegal:
+			location, fields = token.Location, " "+strconv.Quote(token.Reason)
+			if token.Code != "" {
+				fields = " " + token.Code + fields
+			}
+		case *Eof:
+			location = token.Location
+		}
+
+		if _, err := fmt.Fprintf(b, "%d:%d %s%s\n", location.Line, location.Column, token.Kind(), fields); err != nil {
+			return err
+		}
+	}
+	return b.Flush()
+}
+
+func dumpAttributes(token *StartTag) string {
+	var b strings.Builder
+	for _, name := range slices.Sorted(maps.Keys(token.Attributes)) {
+		b.WriteString(" " + name)
+		if value := token.Attributes[name].Value; value != "" {
+			b.WriteString("=" + strconv.Quote(value))
+		}
+	}
+	for _, expression := range token.Expressions {
+		b.WriteString(" {{" + strconv.Quote(expression.Value) + "}}")
+	}
+	if token.IsSelfClosing {
+		b.WriteString(" /")
+	}
+	return b.String()
+}
+
+// ParseDump reads tokens written by Dump. Empty lines and lines starting with `#` are skipped, so golden files may
+// be annotated. Parsed tokens have a zero Cursor and no attribute locations, as the format does not record them.
+func ParseDump(r io.Reader) ([]Token, error) {
+	var tokens []Token
+
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		token, err := parseDumpLine(line)
+		if err != nil {
+			return tokens, fmt.Errorf("line %d: %w", n, err)
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens, scanner.Err()
+}
+
+func parseDumpLine(line string) (Token, error) {
+	var location Location
+	position, rest, _ := strings.Cut(line, " ")
+	if _, err := fmt.Sscanf(position, "%d:%d", &location.Line, &location.Column); err != nil {
+		return nil, fmt.Errorf("invalid position %q", position)
+	}
+	kind, fields, _ := strings.Cut(rest, " ")
+
+	switch kind {
+	case "DOCTYPE":
+		if fields != "" && fields != "system" {
+			return nil, fmt.Errorf("unexpected doctype fields %q", fields)
+		}
+		return &Doctype{fields == "system", location}, nil
+	case "START_TAG":
+		return parseDumpStartTag(fields, location)
+	case "END_TAG":
+		if fields == "" {
+			return nil, fmt.Errorf("missing end tag name")
+		}
+		tag := &EndTag{Name: fields, Location: location}
+		tag.Atom, _ = intern([]rune(tag.Name))
+		return tag, nil
+	case "TEXT", "EXPRESSION", "ILLEGAL":
+		var code string
+		if kind == "ILLEGAL" && !strings.HasPrefix(fields, `"`) {
+			code, fields, _ = strings.Cut(fields, " ")
+		}
+		value, err := strconv.Unquote(fields)
+		if err != nil {
+			return nil, fmt.Errorf("invalid string %s", fields)
+		}
+		switch kind {
+		case "TEXT":
+			return &Text{value, location}, nil
+		case "EXPRESSION":
+			return &Expression{value, location}, nil
+		}
+		return &Illegal{Reason: value, Location: location, Code: code}, nil
+	case "EOF":
+		return &Eof{location}, nil
+	}
+	return nil, fmt.Errorf("unknown token kind %q", kind)
+}
+
+func parseDumpStartTag(fields string, location Location) (*StartTag, error) {
+	name, rest, _ := strings.Cut(fields, " ")
+	if name == "" {
+		return nil, fmt.Errorf("missing start tag name")
+	}
+
+	tag := &StartTag{Name: name, Attributes: map[string]Attribute{}, Location: location}
+	tag.Atom, _ = intern([]rune(name))
+
+	for rest = strings.TrimLeft(rest, " "); rest != ""; rest = strings.TrimLeft(rest, " ") {
+		switch {
+		case rest == "/":
+			tag.IsSelfClosing = true
+			rest = ""
+		case strings.HasPrefix(rest, "{{"):
+			quoted, err := strconv.QuotedPrefix(rest[2:])
+			if err != nil || !strings.HasPrefix(rest[2+len(quoted):], "}}") {
+				return nil, fmt.Errorf("invalid expression in %q", rest)
+			}
+			value, _ := strconv.Unquote(quoted)
+			tag.Expressions = append(tag.Expressions, &Expression{Value: value})
+			rest = rest[2+len(quoted)+2:]
+		default:
+			end := strings.IndexAny(rest, "= ")
+			if end < 0 {
+				end = len(rest)
+			}
+			attribute := Attribute{Name: rest[:end]}
+			attribute.Atom, _ = intern([]rune(attribute.Name))
+			rest = rest[end:]
+
+			if strings.HasPrefix(rest, "=") {
+				quoted, err := strconv.QuotedPrefix(rest[1:])
+				if err != nil {
+					return nil, fmt.Errorf("invalid value of attribute %q", attribute.Name)
+				}
+				attribute.Value, _ = strconv.Unquote(quoted)
+				rest = rest[1+len(quoted):]
+			}
+			tag.Attributes[attribute.Name] = attribute
+		}
+	}
+	return tag, nil
+}
@@ -0,0 +1,63 @@
+package html
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDump(t *testing.T) {
+	template := "<!DOCTYPE html>\n<div id=\"main\" class='a \"b\"' hidden {{ attrs }}>Hello\n{{ name }}<br/></div>"
+
+	tokenizer := NewTokenizer(template)
+	tokenizer.EnableExpressions(DefaultDelimiters)
+
+	var b strings.Builder
+	if err := Dump(&b, tokenizer.All()); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `1:1 DOCTYPE
+1:16 TEXT "\n"
+2:1 START_TAG div class="a \"b\"" hidden id="main" {{" attrs "}}
+2:49 TEXT "Hello\n"
+3:1 EXPRESSION " name "
+3:11 START_TAG br /
+3:16 END_TAG div
+`
+	if b.String() != expected {
+		t.Errorf("got\n%s\nexpected\n%s", b.String(), expected)
+	}
+
+	tokens, err := ParseDump(strings.NewReader("# golden file\n\n" + b.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var again strings.Builder
+	if err := Dump(&again, func(yield func(Token) bool) {
+		for _, token := range tokens {
+			if !yield(token) {
+				return
+			}
+		}
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if again.String() != expected {
+		t.Errorf("dump of parsed tokens differs:\n%s", again.String())
+	}
+}
+
+func TestParseDumpErrors(t *testing.T) {
+	for _, dump := range []string{
+		"1 TEXT \"a\"",
+		"1:1 UNKNOWN",
+		"1:1 TEXT a",
+		"1:1 START_TAG div id=unquoted",
+		"1:1 START_TAG div {{\"a\"",
+	} {
+		if _, err := ParseDump(strings.NewReader(dump)); err == nil {
+			t.Errorf("%q: expected an error", dump)
+		}
+	}
+}
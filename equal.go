@@ -0,0 +1,179 @@
+package html
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+type CompareOptions struct {
+	// MaxDifferences limits the number of reported differences, 0 reporting all of them.
+	MaxDifferences int
+	// PreserveWhitespace compares text exactly instead of collapsing whitespace and ignoring whitespace-only text.
+	PreserveWhitespace bool
+}
+
+// Difference describes where two templates differ, A and B being the locations in each.
+type Difference struct {
+	Message string
+	A       Location
+	B       Location
+}
+
+func (d Difference) String() string {
+	return fmt.Sprintf("%d:%d / %d:%d: %s", d.A.Line, d.A.Column, d.B.Line, d.B.Column, d.Message)
+}
+
+// Equal compares two templates token by token, ignoring attribute order, quoting, case of names, the self-closing
+// slash of void elements and, unless preserved, insignificant whitespace. Text inside `<pre>` and `<textarea>` is
+// always compared exactly. Differences in attributes and text are reported while comparing continues, whereas the
+// first structural difference, such as differing tags, ends the comparison since the token streams no longer
+// correspond.
+func Equal(a, b string, opts CompareOptions) (bool, []Difference) {
+	x, y := comparableTokens(a, opts), comparableTokens(b, opts)
+
+	var differences []Difference
+	report := func(p, q comparableToken, format string, args ...any) bool {
+		differences = append(differences, Difference{fmt.Sprintf(format, args...), p.Location, q.Location})
+		return opts.MaxDifferences == 0 || len(differences) < opts.MaxDifferences
+	}
+
+	for i := range min(len(x), len(y)) {
+		p, q := x[i], y[i]
+		if p.kind != q.kind || p.name != q.name {
+			report(p, q, "%s differs from %s", p.describe(), q.describe())
+			break
+		}
+
+		if p.value != q.value {
+			var more bool
+			switch p.kind {
+			case "START_TAG":
+				more = report(p, q, "self-closing slash of <%s> differs", p.name)
+			case "DOCTYPE":
+				more = report(p, q, "doctype differs")
+			default:
+				more = report(p, q, "%s differs: %q != %q", strings.ToLower(p.kind), p.value, q.value)
+			}
+			if !more {
+				break
+			}
+		}
+
+		names := slices.Sorted(maps.Keys(p.attributes))
+		for _, name := range slices.Sorted(maps.Keys(q.attributes)) {
+			if _, ok := p.attributes[name]; !ok {
+				names = append(names, name)
+			}
+		}
+		for _, name := range names {
+			v, inA := p.attributes[name]
+			w, inB := q.attributes[name]
+			var more bool
+			switch {
+			case !inB:
+				more = report(p, q, "attribute %q of <%s> missing in b", name, p.name)
+			case !inA:
+				more = report(p, q, "attribute %q of <%s> missing in a", name, p.name)
+			case v != w:
+				more = report(p, q, "attribute %q of <%s> differs: %q != %q", name, p.name, v, w)
+			default:
+				continue
+			}
+			if !more {
+				return false, differences
+			}
+		}
+	}
+
+	return len(differences) == 0, differences
+}
+
+type comparableToken struct {
+	kind       string
+	name       string
+	value      string
+	attributes map[string]string
+	Location
+}
+
+func (t comparableToken) describe() string {
+	switch t.kind {
+	case "START_TAG":
+		return "<" + t.name + ">"
+	case "END_TAG":
+		return "</" + t.name + ">"
+	case "EOF":
+		return "end of input"
+	}
+	return strings.ToLower(t.kind)
+}
+
+// comparableTokens normalizes the tokens of a template, always ending with EOF so that a shorter template differs
+// structurally from a longer one.
+func comparableTokens(template string, opts CompareOptions) []comparableToken {
+	var tokens []comparableToken
+	var text strings.Builder
+	var textLocation Location
+	preserve := 0
+
+	flush := func() {
+		value := text.String()
+		text.Reset()
+		if !opts.PreserveWhitespace && preserve == 0 {
+			value = strings.Join(strings.FieldsFunc(value, isWhitespace), " ")
+		}
+		if value != "" {
+			tokens = append(tokens, comparableToken{kind: "TEXT", value: value, Location: textLocation})
+		}
+	}
+
+	t := NewTokenizer(template)
+	for {
+		start := t.i
+		token := t.next()
+		if _, ok := token.(*Text); !ok && text.Len() > 0 {
+			flush()
+		}
+
+		switch token := token.(type) {
+		case *Eof:
+			return append(tokens, comparableToken{kind: "EOF", Location: token.Location})
+		case *Text:
+			if text.Len() == 0 {
+				textLocation = token.Location
+			}
+			text.WriteString(token.Value)
+		case *StartTag:
+			name := strings.ToLower(token.Name)
+			attributes := make(map[string]string, len(token.Attributes))
+			for _, attribute := range token.Attributes {
+				attributes[strings.ToLower(attribute.Name)] = attribute.Value
+			}
+			value := ""
+			if token.IsSelfClosing && !isVoidElement(name) {
+				value = "/"
+			}
+			tokens = append(tokens, comparableToken{"START_TAG", name, value, attributes, token.Location})
+			if (name == "pre" || name == "textarea") && !token.IsSelfClosing {
+				preserve++
+			}
+		case *EndTag:
+			name := strings.ToLower(token.Name)
+			tokens = append(tokens, comparableToken{kind: "END_TAG", name: name, Location: token.Location})
+			if name == "pre" || name == "textarea" {
+				preserve = max(preserve-1, 0)
+			}
+		case *Doctype:
+			tokens = append(tokens, comparableToken{kind: "DOCTYPE", value: fmt.Sprint(token.HasSystem), Location: token.Location})
+		case *Expression:
+			tokens = append(tokens, comparableToken{kind: "EXPRESSION", value: strings.TrimSpace(token.Value), Location: token.Location})
+		case *Illegal:
+			tokens = append(tokens, comparableToken{kind: "ILLEGAL", value: token.Reason, Location: token.Location})
+			if t.i == start {
+				return append(tokens, comparableToken{kind: "EOF", Location: t.location()})
+			}
+		}
+	}
+}
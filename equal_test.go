@@ -0,0 +1,62 @@
+package html
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEqual(t *testing.T) {
+	a := `<div class="card" id='x'>
+	<p>Hello
+	   world</p>
+	<br/>
+</div>`
+	b := `<DIV id="x" class='card'><p>Hello world</p><br></DIV>`
+
+	if equal, differences := Equal(a, b, CompareOptions{}); !equal {
+		t.Errorf("expected equal templates, got %v", differences)
+	}
+	if equal, _ := Equal(a, b, CompareOptions{PreserveWhitespace: true}); equal {
+		t.Error("expected whitespace to matter when preserved")
+	}
+	if equal, _ := Equal("<pre>a  b</pre>", "<pre>a b</pre>", CompareOptions{}); equal {
+		t.Error("expected whitespace in <pre> to matter")
+	}
+}
+
+func TestEqualDifferences(t *testing.T) {
+	a := "<p class=\"a\" title=\"t\">one</p>\n<p>two</p>"
+	b := "<p class=\"b\" lang=\"en\" dir=\"ltr\">uno</p>\n<span>two</span>"
+
+	equal, differences := Equal(a, b, CompareOptions{})
+	if equal {
+		t.Fatal("expected templates to differ")
+	}
+
+	var messages []string
+	for _, difference := range differences {
+		messages = append(messages, difference.Message)
+	}
+	expected := []string{
+		`attribute "class" of <p> differs: "a" != "b"`,
+		`attribute "title" of <p> missing in b`,
+		`attribute "dir" of <p> missing in a`,
+		`attribute "lang" of <p> missing in a`,
+		`text differs: "one" != "uno"`,
+		`<p> differs from <span>`,
+	}
+	if !reflect.DeepEqual(messages, expected) {
+		t.Errorf("got %q, expected %q", messages, expected)
+	}
+
+	if last := differences[len(differences)-1]; last.A != (Location{2, 1, 31}) || last.B != (Location{2, 1, 41}) {
+		t.Errorf("unexpected locations %v", last)
+	}
+
+	if _, differences := Equal(a, b, CompareOptions{MaxDifferences: 2}); len(differences) != 2 {
+		t.Errorf("expected 2 differences, got %d", len(differences))
+	}
+	if _, differences := Equal("<p>a</p>", "<p>a</p><p>b</p>", CompareOptions{}); len(differences) != 1 || differences[0].Message != "end of input differs from <p>" {
+		t.Errorf("unexpected differences %v", differences)
+	}
+}
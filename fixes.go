@@ -0,0 +1,156 @@
+package html
+
+import (
+	"slices"
+	"strings"
+)
+
+// optionalEndTagElements may be closed implicitly, so they are not reported as unclosed.
+// https://html.spec.whatwg.org/multipage/syntax.html#optional-tags
+var optionalEndTagElements = []string{
+	"html", "head", "body", "p", "li", "dt", "dd", "option", "optgroup", "rt", "rp",
+	"tr", "td", "th", "thead", "tbody", "tfoot", "colgroup", "caption",
+}
+
+type openElement struct {
+	name     string
+	location Location
+}
+
+// Lint is like Diagnostics, additionally reporting warnings with fixes for duplicate attributes, images without alt
+// text and elements which are never closed, coded "duplicate-attribute", "missing-alt" and "unclosed-element".
+// Elements whose end tag may be omitted are not reported as unclosed. Fixes closing elements at the same location
+// are listed innermost first, as ApplyFixes expects.
+func (t *Tokenizer) Lint() DiagnosticList {
+	return t.diagnose(true)
+}
+
+func (t *Tokenizer) lintStartTag(raw *RawToken) DiagnosticList {
+	var diagnostics DiagnosticList
+	name := string(raw.Name)
+
+	for i, attribute := range raw.Attributes {
+		if !slices.ContainsFunc(raw.Attributes[:i], func(previous RawAttribute) bool { return equalFold(previous.Name, attribute.Name) }) {
+			continue
+		}
+		// Removing the attribute along with the whitespace before it leaves the rest of the tag intact.
+		diagnostics = append(diagnostics, Diagnostic{
+			Filename: t.filename,
+			Range:    Range{attribute.NameLocation, attributeEnd(attribute)},
+			Severity: "warning",
+			Code:     "duplicate-attribute",
+			Message:  "duplicate attribute " + string(attribute.Name) + " on <" + name + ">, only the first is used",
+			Fix:      []TextEdit{{Range{t.whitespaceBefore(attribute.NameLocation), attributeEnd(attribute)}, ""}},
+		})
+	}
+
+	if isElement(raw.Name, "img") && !raw.lazy && !slices.ContainsFunc(raw.Attributes, func(attribute RawAttribute) bool { return isElement(attribute.Name, "alt") }) {
+		selection := nameRange(raw.Location, name)
+		diagnostics = append(diagnostics, Diagnostic{
+			Filename: t.filename,
+			Range:    selection,
+			Severity: "warning",
+			Code:     "missing-alt",
+			Message:  "<" + name + "> without alt text",
+			Fix:      []TextEdit{{Range{selection.End, selection.End}, ` alt=""`}},
+		})
+	}
+	return diagnostics
+}
+
+// unclosed reports the open elements as closed at the location, inserting their end tags there.
+func (t *Tokenizer) unclosed(open []openElement, location Location) DiagnosticList {
+	var diagnostics DiagnosticList
+	for i := len(open) - 1; i >= 0; i-- {
+		if slices.Contains(optionalEndTagElements, strings.ToLower(open[i].name)) {
+			continue
+		}
+		diagnostics = append(diagnostics, Diagnostic{
+			Filename: t.filename,
+			Range:    nameRange(open[i].location, open[i].name),
+			Severity: "warning",
+			Code:     "unclosed-element",
+			Message:  "<" + open[i].name + "> is never closed",
+			Fix:      []TextEdit{{Range{location, location}, "</" + open[i].name + ">"}},
+		})
+	}
+	return diagnostics
+}
+
+// quoteFix returns the edits quoting the unquoted attribute value at the location, which reaches up to whitespace or
+// the end of the tag, a slash before `>` being taken as closing the tag rather than as part of the value. Values
+// containing both kinds of quotes cannot be fixed.
+func (t *Tokenizer) quoteFix(location Location) []TextEdit {
+	end := location
+	for end.Cursor < len(t.template) {
+		c := t.template[end.Cursor]
+		if isWhitespace(c) || c == '>' || c == '/' && end.Cursor+1 < len(t.template) && t.template[end.Cursor+1] == '>' {
+			break
+		}
+		end = advanceLocation(end, c)
+	}
+
+	value := t.template[location.Cursor:end.Cursor]
+	if len(value) == 0 || slices.Contains(value, '"') && slices.Contains(value, '\'') {
+		return nil
+	}
+	quote := `"`
+	if slices.Contains(value, '"') {
+		quote = `'`
+	}
+	return []TextEdit{{Range{location, location}, quote}, {Range{end, end}, quote}}
+}
+
+func attributeEnd(attribute RawAttribute) Location {
+	if attribute.ValueLocation != (Location{}) {
+		return attribute.ValueEnd
+	}
+	return attribute.NameEnd
+}
+
+// whitespaceBefore returns the start of the whitespace preceding the location on its line.
+func (t *Tokenizer) whitespaceBefore(location Location) Location {
+	for location.Column > 1 && isWhitespace(t.template[location.Cursor-1]) {
+		location.Column--
+		location.Cursor--
+	}
+	return location
+}
+
+// ApplyFixes applies the fixes of the diagnostics to the template. A diagnostic whose edits overlap those of an
+// earlier one is skipped, so fixing again after linting the result may fix more. Insertions at the same location are
+// made in the order of the diagnostics.
+func ApplyFixes(template string, diagnostics []Diagnostic) string {
+	var edits []TextEdit
+	for _, diagnostic := range diagnostics {
+		if len(diagnostic.Fix) == 0 {
+			continue
+		}
+		conflicts := slices.ContainsFunc(diagnostic.Fix, func(edit TextEdit) bool {
+			return slices.ContainsFunc(edits, edit.overlaps)
+		})
+		if !conflicts {
+			edits = append(edits, diagnostic.Fix...)
+		}
+	}
+	slices.SortStableFunc(edits, func(a, b TextEdit) int { return a.Start.Cursor - b.Start.Cursor })
+
+	source := []rune(template)
+	var b strings.Builder
+	cursor := 0
+	for _, edit := range edits {
+		b.WriteString(string(source[cursor:edit.Start.Cursor]))
+		b.WriteString(edit.NewText)
+		cursor = edit.End.Cursor
+	}
+	b.WriteString(string(source[cursor:]))
+	return b.String()
+}
+
+// overlaps reports whether the edits touch the same text, an insertion overlapping an edit only when strictly inside
+// the range it replaces.
+func (e TextEdit) overlaps(other TextEdit) bool {
+	return max(e.Start.Cursor, other.Start.Cursor) < min(e.End.Cursor, other.End.Cursor) ||
+		e.Start == e.End && other.Start.Cursor < e.Start.Cursor && e.Start.Cursor < other.End.Cursor ||
+		other.Start == other.End && e.Start.Cursor < other.Start.Cursor && other.Start.Cursor < e.End.Cursor
+}
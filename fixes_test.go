@@ -0,0 +1,54 @@
+package html
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	template := "<div><img src=\"a.png\" class=\"x\" CLASS=\"y\"><span><b>bold\n</div><section>"
+
+	tokenizer := NewTokenizer(template)
+	var got []string
+	for _, diagnostic := range tokenizer.Lint() {
+		got = append(got, diagnostic.Code+" "+diagnostic.Error())
+	}
+
+	expected := []string{
+		"duplicate-attribute 1:33: duplicate attribute CLASS on <img>, only the first is used",
+		"missing-alt 1:7: <img> without alt text",
+		"unclosed-element 1:50: <b> is never closed",
+		"unclosed-element 1:44: <span> is never closed",
+		"unclosed-element 2:8: <section> is never closed",
+	}
+	if !slices.Equal(got, expected) {
+		t.Errorf("got %q, expected %q", got, expected)
+	}
+}
+
+func TestApplyFixes(t *testing.T) {
+	tests := map[string]string{
+		"<div><img src=\"a.png\" class=\"x\" CLASS=\"y\"><span><b>bold\n</div>": "<div><img alt=\"\" src=\"a.png\" class=\"x\"><span><b>bold\n</b></span></div>",
+		"<p title=x y>text</p>":                "<p title=\"x\" y>text</p>",
+		"<p title=say\"hi\">":                  "<p title='say\"hi\"'>",
+		"<br id=x/>":                           "<br id=\"x\"/>",
+		"<ul><li>one<li>two</ul><p>open":       "<ul><li>one<li>two</ul><p>open",
+		"<a href=\"/\" href=\"/x\" href>a</a>": "<a href=\"/\">a</a>",
+	}
+
+	for template, expected := range tests {
+		tokenizer := NewTokenizer(template)
+		if got := ApplyFixes(template, tokenizer.Lint()); got != expected {
+			t.Errorf("%q: got %q, expected %q", template, got, expected)
+		}
+	}
+}
+
+func TestApplyFixesKeepsExpressions(t *testing.T) {
+	template := "<p id=\"a\" {{ x }} ID=\"b\">"
+	tokenizer := NewTokenizer(template)
+	tokenizer.EnableExpressions(DefaultDelimiters)
+	if got, expected := ApplyFixes(template, tokenizer.Lint()), "<p id=\"a\" {{ x }}>"; got != expected {
+		t.Errorf("got %q, expected %q", got, expected)
+	}
+}
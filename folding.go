@@ -0,0 +1,94 @@
+package html
+
+import (
+	"iter"
+	"slices"
+	"strings"
+)
+
+// voidElements never have an end tag, so they are not expected to be closed.
+var voidElements = []string{
+	"area", "base", "br", "col", "embed", "hr", "img", "input", "link", "meta", "param", "source", "track", "wbr",
+}
+
+func isVoidElement(name string) bool {
+	return slices.Contains(voidElements, strings.ToLower(name))
+}
+
+type FoldingRange struct {
+	// Name is the lowercase name of the element whose body folds, or `#comment` for comments.
+	Name      string
+	StartLine int
+	EndLine   int
+}
+
+// FoldingRanges returns the elements spanning multiple lines, from the line of the start tag to the line of the end
+// tag, ordered by their start. Script and style blocks fold like any other element. Elements left unclosed, either
+// at the end of input or by the end tag of an ancestor, do not fold. Comments and other markup declarations spanning
+// multiple lines fold as well.
+func FoldingRanges(tokens iter.Seq[Token]) ([]FoldingRange, error) {
+	var (
+		ranges []FoldingRange
+		// stack holds the indices in ranges of the open elements.
+		stack []int
+		// comment is the index in ranges of the comment ending where the next token starts, -1 if there is none.
+		comment = -1
+	)
+
+	folded := func() []FoldingRange {
+		return slices.DeleteFunc(ranges, func(r FoldingRange) bool { return r.EndLine <= r.StartLine })
+	}
+
+	for token := range tokens {
+		if comment >= 0 {
+			ranges[comment].EndLine = startLine(token)
+			comment = -1
+		}
+
+		switch token := token.(type) {
+		case *Illegal:
+			if token.Code != CodeMarkupDeclaration {
+				return folded(), token
+			}
+			comment = len(ranges)
+			ranges = append(ranges, FoldingRange{Name: "#comment", StartLine: token.Line})
+		case *StartTag:
+			if !token.IsSelfClosing && !isVoidElement(token.Name) {
+				stack = append(stack, len(ranges))
+				ranges = append(ranges, FoldingRange{Name: strings.ToLower(token.Name), StartLine: token.Line})
+			}
+		case *EndTag:
+			name := strings.ToLower(token.Name)
+			for i := len(stack) - 1; i >= 0; i-- {
+				if ranges[stack[i]].Name == name {
+					ranges[stack[i]].EndLine = token.Line
+					stack = stack[:i]
+					break
+				}
+			}
+		}
+	}
+
+	return folded(), nil
+}
+
+// startLine returns the line at which the token starts.
+func startLine(token Token) int {
+	switch token := token.(type) {
+	case *Doctype:
+		return token.Line
+	case *StartTag:
+		return token.Line
+	case *EndTag:
+		return token.Line
+	case *Text:
+		return token.Line
+	case *Expression:
+		return token.Line
+	case *Illegal:
+		return token.Line
+	case *Eof:
+		return token.Line
+	}
+	return 0
+}
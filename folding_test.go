@@ -0,0 +1,44 @@
+package html
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFoldingRanges(t *testing.T) {
+	template := `<html>
+<body>
+	<ul>
+		<li>one
+		<li>two
+	</ul>
+	<p>single line</p>
+	<!-- a comment
+	  over two lines --><!-- single line -->
+	<br>
+	<img src="a.png">
+	<script>
+		if (a < b) {}
+	</script>
+	<div><span>
+	</div>
+</body>
+</html><!-- end -->`
+
+	ranges, err := FoldingRanges(Tokenize(template))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []FoldingRange{
+		{"html", 1, 18},
+		{"body", 2, 17},
+		{"ul", 3, 6},
+		{"#comment", 8, 9},
+		{"script", 12, 14},
+		{"div", 15, 16},
+	}
+	if !reflect.DeepEqual(ranges, expected) {
+		t.Errorf("got %v, expected %v", ranges, expected)
+	}
+}
@@ -0,0 +1,172 @@
+package html
+
+import (
+	"iter"
+	"strings"
+)
+
+type Form struct {
+	Action string
+	// Method is lowercased and defaults to `get`.
+	Method string
+	// Enctype defaults to `application/x-www-form-urlencoded`.
+	Enctype string
+	Fields  []Field
+	Location
+}
+
+type Field struct {
+	// Tag is one of `input`, `select`, `textarea` or `button`.
+	Tag  string
+	Name string
+	// Type is lowercased and defaults to `text` for inputs, `submit` for buttons and the tag name otherwise.
+	Type     string
+	Value    string
+	Options  []Option
+	Checked  bool
+	Disabled bool
+	Location
+}
+
+type Option struct {
+	Value    string
+	Label    string
+	Selected bool
+	Disabled bool
+}
+
+// ExtractForms returns every form in the token stream together with the fields nested in it.
+// https://html.spec.whatwg.org/multipage/forms.html
+func ExtractForms(tokens iter.Seq[Token]) ([]Form, error) {
+	var forms []Form
+	var form *Form
+	var field *Field
+	var option *Option
+	var optionHasValue bool
+	var text strings.Builder
+
+	finishOption := func() {
+		if option == nil {
+			return
+		}
+		if option.Label = strings.Join(strings.FieldsFunc(text.String(), isWhitespace), " "); !optionHasValue {
+			option.Value = option.Label
+		}
+		field.Options = append(field.Options, *option)
+		option = nil
+	}
+
+	for token := range tokens {
+		switch token := token.(type) {
+		case *Illegal:
+			if token.Code != CodeMarkupDeclaration {
+				return forms, token
+			}
+		case *Text:
+			if option != nil || (field != nil && field.Tag == "textarea") {
+				text.WriteString(token.Value)
+			}
+		case *EndTag:
+			switch strings.ToLower(token.Name) {
+			case "form":
+				if form != nil {
+					forms = append(forms, *form)
+				}
+				form, field, option = nil, nil, nil
+			case "option":
+				finishOption()
+			case "select":
+				if field != nil && field.Tag == "select" {
+					finishOption()
+					form.Fields = append(form.Fields, *field)
+					field = nil
+				}
+			case "textarea":
+				if field != nil && field.Tag == "textarea" {
+					field.Value = strings.TrimPrefix(text.String(), "\n")
+					form.Fields = append(form.Fields, *field)
+					field = nil
+				}
+			}
+		case *StartTag:
+			name := strings.ToLower(token.Name)
+			value := func(name string) string {
+				attribute, _ := token.GetAttribute(name)
+				return attribute.Value
+			}
+			if name == "form" {
+				if form != nil {
+					continue
+				}
+				form = &Form{
+					Action:   value("action"),
+					Method:   strings.ToLower(value("method")),
+					Enctype:  strings.ToLower(value("enctype")),
+					Location: token.Location,
+				}
+				if form.Method == "" {
+					form.Method = "get"
+				}
+				if form.Enctype == "" {
+					form.Enctype = "application/x-www-form-urlencoded"
+				}
+				continue
+			}
+
+			if form == nil {
+				continue
+			}
+
+			disabled := token.HasAttribute("disabled")
+			switch name {
+			case "input", "button", "select", "textarea":
+				if field != nil {
+					continue
+				}
+				current := Field{
+					Tag:      name,
+					Name:     value("name"),
+					Type:     strings.ToLower(value("type")),
+					Value:    value("value"),
+					Disabled: disabled,
+					Location: token.Location,
+				}
+				current.Checked = token.HasAttribute("checked")
+
+				switch {
+				case current.Type != "" && name != "select" && name != "textarea":
+				case name == "input":
+					current.Type = "text"
+				case name == "button":
+					current.Type = "submit"
+				default:
+					current.Type = name
+				}
+
+				if (name == "select" || name == "textarea") && !token.IsSelfClosing {
+					field = &current
+					text.Reset()
+				} else {
+					form.Fields = append(form.Fields, current)
+				}
+			case "option":
+				if field == nil || field.Tag != "select" {
+					continue
+				}
+				finishOption()
+				option = &Option{Disabled: disabled}
+				option.Selected = token.HasAttribute("selected")
+				var attribute Attribute
+				attribute, optionHasValue = token.GetAttribute("value")
+				option.Value = attribute.Value
+				text.Reset()
+			}
+		}
+	}
+
+	if form != nil {
+		forms = append(forms, *form)
+	}
+
+	return forms, nil
+}
@@ -0,0 +1,71 @@
+package html
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractForms(t *testing.T) {
+	template := `<input name="outside">
+<form Action="/login" METHOD="POST">
+	<input name="user" value="bob">
+	<input type="Password" name="password" disabled>
+	<input type="checkbox" NAME="remember" CHECKED>
+	<select name="lang">
+		<option value="en">English</option>
+		<option Selected>  Polski </option>
+		<option value="" disabled>None</option>
+	</select>
+	<textarea name="note">
+hello <b>world</b></textarea>
+	<button>Log in</button>
+</form>
+<form><input name="q"><!-- end -->`
+
+	forms, err := ExtractForms(Tokenize(template))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(forms) != 2 {
+		t.Fatalf("expected 2 forms, got %d", len(forms))
+	}
+
+	login := forms[0]
+	if login.Action != "/login" || login.Method != "post" || login.Enctype != "application/x-www-form-urlencoded" {
+		t.Errorf("unexpected form attributes: %+v", login)
+	}
+
+	type field struct {
+		Tag, Name, Type, Value string
+		Checked, Disabled      bool
+	}
+	var fields []field
+	for _, f := range login.Fields {
+		fields = append(fields, field{f.Tag, f.Name, f.Type, f.Value, f.Checked, f.Disabled})
+	}
+
+	expected := []field{
+		{"input", "user", "text", "bob", false, false},
+		{"input", "password", "password", "", false, true},
+		{"input", "remember", "checkbox", "", true, false},
+		{"select", "lang", "select", "", false, false},
+		{"textarea", "note", "textarea", "hello <b>world</b>", false, false},
+		{"button", "", "submit", "", false, false},
+	}
+	if !reflect.DeepEqual(fields, expected) {
+		t.Errorf("expected fields %+v, got %+v", expected, fields)
+	}
+
+	options := []Option{
+		{Value: "en", Label: "English"},
+		{Value: "Polski", Label: "Polski", Selected: true},
+		{Value: "", Label: "None", Disabled: true},
+	}
+	if !reflect.DeepEqual(login.Fields[3].Options, options) {
+		t.Errorf("expected options %+v, got %+v", options, login.Fields[3].Options)
+	}
+
+	if forms[1].Method != "get" || len(forms[1].Fields) != 1 {
+		t.Errorf("unexpected second form: %+v", forms[1])
+	}
+}
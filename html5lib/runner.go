@@ -0,0 +1,238 @@
+// Package html5lib runs the tokenizer against test suites written in the html5lib-tests tokenizer format.
+// https://github.com/html5lib/html5lib-tests/tree/master/tokenizer
+package html5lib
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/terawatthour/html"
+)
+
+// DataState is the only initial state the tokenizer can be started in, tests for other states are skipped.
+const DataState = "Data state"
+
+type Test struct {
+	Description   string   `json:"description"`
+	Input         string   `json:"input"`
+	Output        []any    `json:"output"`
+	InitialStates []string `json:"initialStates"`
+	LastStartTag  string   `json:"lastStartTag"`
+	DoubleEscaped bool     `json:"doubleEscaped"`
+}
+
+type Result struct {
+	Test    Test
+	State   string
+	Passed  bool
+	Skipped bool
+	// Actual is the tokenizer output converted to the html5lib format, nil for skipped tests.
+	Actual []any
+}
+
+type Summary struct {
+	Passed  int
+	Failed  int
+	Skipped int
+}
+
+type Report struct {
+	Results []Result
+	States  map[string]*Summary
+}
+
+// Load reads a tokenizer test file, i.e. a JSON object with a `tests` array.
+func Load(r io.Reader) ([]Test, error) {
+	var file struct {
+		Tests []Test `json:"tests"`
+	}
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return nil, err
+	}
+
+	for i, test := range file.Tests {
+		if len(test.InitialStates) == 0 {
+			file.Tests[i].InitialStates = []string{DataState}
+		}
+		if test.DoubleEscaped {
+			file.Tests[i].Input = unescape(test.Input)
+			file.Tests[i].Output = unescapeOutput(test.Output)
+		}
+	}
+
+	return file.Tests, nil
+}
+
+func LoadFile(path string) ([]Test, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Load(f)
+}
+
+// Run runs the test once for every initial state it declares.
+func Run(test Test) []Result {
+	results := make([]Result, 0, len(test.InitialStates))
+	for _, state := range test.InitialStates {
+		result := Result{Test: test, State: state}
+		if state != DataState || test.LastStartTag != "" {
+			result.Skipped = true
+		} else {
+			result.Actual = Tokenize(test.Input)
+			result.Passed = reflect.DeepEqual(result.Actual, normalize(test.Output))
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+func RunAll(tests []Test) Report {
+	report := Report{States: make(map[string]*Summary)}
+	for _, test := range tests {
+		for _, result := range Run(test) {
+			summary, ok := report.States[result.State]
+			if !ok {
+				summary = &Summary{}
+				report.States[result.State] = summary
+			}
+
+			switch {
+			case result.Skipped:
+				summary.Skipped++
+			case result.Passed:
+				summary.Passed++
+			default:
+				summary.Failed++
+			}
+			report.Results = append(report.Results, result)
+		}
+	}
+	return report
+}
+
+// String lists the failed tests followed by the pass/fail counts of every initial state.
+func (r Report) String() string {
+	var b strings.Builder
+	for _, result := range r.Results {
+		if !result.Passed && !result.Skipped {
+			fmt.Fprintf(&b, "FAIL [%s] %s\n\tinput:    %q\n\texpected: %v\n\tactual:   %v\n", result.State, result.Test.Description, result.Test.Input, normalize(result.Test.Output), result.Actual)
+		}
+	}
+
+	states := make([]string, 0, len(r.States))
+	for state := range r.States {
+		states = append(states, state)
+	}
+	slices.Sort(states)
+
+	for _, state := range states {
+		summary := r.States[state]
+		fmt.Fprintf(&b, "%s: %d passed, %d failed, %d skipped\n", state, summary.Passed, summary.Failed, summary.Skipped)
+	}
+	return b.String()
+}
+
+// Tokenize converts the tokenizer output to html5lib tokens. Adjacent character tokens are merged and illegal
+// tokens are reported as parse errors, which are otherwise absent from the expected output.
+func Tokenize(input string) []any {
+	var output []any
+	for token := range html.Tokenize(input) {
+		switch token := token.(type) {
+		case *html.Doctype:
+			system := any(nil)
+			if token.HasSystem {
+				system = "about:legacy-compat"
+			}
+			output = append(output, []any{"DOCTYPE", "html", nil, system, true})
+		case *html.StartTag:
+			attributes := make(map[string]any, len(token.Attributes))
+			for name, attribute := range token.Attributes {
+				attributes[strings.ToLower(name)] = attribute.Value
+			}
+			tag := []any{"StartTag", strings.ToLower(token.Name), attributes}
+			if token.IsSelfClosing {
+				tag = append(tag, true)
+			}
+			output = append(output, tag)
+		case *html.EndTag:
+			output = append(output, []any{"EndTag", strings.ToLower(token.Name)})
+		case *html.Text:
+			output = appendCharacters(output, token.Value)
+		case *html.Illegal:
+			output = append(output, "ParseError")
+		}
+	}
+	return output
+}
+
+// normalize merges adjacent character tokens and drops parse errors present in older versions of the format.
+func normalize(expected []any) []any {
+	var output []any
+	for _, token := range expected {
+		fields, ok := token.([]any)
+		if !ok {
+			continue
+		}
+		if data, ok := fields[1].(string); ok && fields[0] == "Character" {
+			output = appendCharacters(output, data)
+			continue
+		}
+		output = append(output, fields)
+	}
+	return output
+}
+
+func appendCharacters(output []any, data string) []any {
+	if data == "" {
+		return output
+	}
+	if len(output) > 0 {
+		if last, ok := output[len(output)-1].([]any); ok && last[0] == "Character" {
+			output[len(output)-1] = []any{"Character", last[1].(string) + data}
+			return output
+		}
+	}
+	return append(output, []any{"Character", data})
+}
+
+func unescapeOutput(output []any) []any {
+	for i, token := range output {
+		switch token := token.(type) {
+		case string:
+			output[i] = unescape(token)
+		case []any:
+			output[i] = unescapeOutput(token)
+		case map[string]any:
+			for key, value := range token {
+				if value, ok := value.(string); ok {
+					token[key] = unescape(value)
+				}
+			}
+		}
+	}
+	return output
+}
+
+// unescape decodes the `\uXXXX` escapes of double-escaped tests. Lone surrogates become U+FFFD.
+func unescape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+6 <= len(s) && s[i+1] == 'u' {
+			if code, err := strconv.ParseUint(s[i+2:i+6], 16, 32); err == nil {
+				b.WriteRune(rune(code))
+				i += 5
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
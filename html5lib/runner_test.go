@@ -0,0 +1,52 @@
+package html5lib
+
+import (
+	"strings"
+	"testing"
+)
+
+const suite = `{"tests": [
+{"description": "Start and end tags",
+"input": "<div class='a'>Hi</div>",
+"output": [["StartTag", "div", {"class": "a"}], ["Character", "Hi"], ["EndTag", "div"]]},
+
+{"description": "Self-closing tag with split characters",
+"input": "a<br/>b\\u0041",
+"doubleEscaped": true,
+"output": [["Character", "a"], ["StartTag", "br", {}, true], ["Character", "b"], ["Character", "\\u0041"]]},
+
+{"description": "Comment",
+"input": "<!-- x -->",
+"output": [["Comment", " x "]]},
+
+{"description": "RAWTEXT end tag",
+"initialStates": ["RAWTEXT state", "Data state"],
+"input": "x",
+"output": [["Character", "x"]]}
+]}`
+
+func TestRunAll(t *testing.T) {
+	tests, err := Load(strings.NewReader(suite))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tests) != 4 {
+		t.Fatalf("expected 4 tests, got %d", len(tests))
+	}
+	if tests[1].Input != "a<br/>bA" {
+		t.Errorf("double-escaped input was not unescaped: %q", tests[1].Input)
+	}
+
+	report := RunAll(tests)
+
+	data := report.States[DataState]
+	if data.Passed != 3 || data.Failed != 1 || data.Skipped != 0 {
+		t.Errorf("unexpected data state summary: %+v", *data)
+	}
+	if rawText := report.States["RAWTEXT state"]; rawText.Skipped != 1 {
+		t.Errorf("unexpected RAWTEXT state summary: %+v", *rawText)
+	}
+	if output := report.String(); !strings.Contains(output, "FAIL [Data state] Comment") || !strings.Contains(output, "Data state: 3 passed, 1 failed, 0 skipped") {
+		t.Errorf("unexpected report:\n%s", output)
+	}
+}
@@ -0,0 +1,215 @@
+// Package htmlgen generates random HTML documents together with the tokens the tokenizer must produce for them,
+// for property-based tests of the tokenizer and of code consuming its tokens.
+package htmlgen
+
+import (
+	"math/rand/v2"
+	"strings"
+
+	"github.com/terawatthour/html"
+	"github.com/terawatthour/html/atom"
+)
+
+var (
+	containerElements = []string{"div", "p", "span", "a", "ul", "li", "section", "h1", "h2", "em", "custom-element"}
+	voidElements      = []string{"br", "img", "input", "hr", "meta"}
+	rawTextElements   = []string{"script", "style", "title", "textarea"}
+	attributeNames    = []string{"id", "class", "href", "title", "data-value", "aria-label", "hidden", "disabled"}
+	words             = []string{"lorem", "ipsum", "dolor", "sit", "amet", "&amp;", "&lt;", "42", "ünïcödé", "日本語", "a > b"}
+)
+
+type Options struct {
+	// Depth limits the nesting of elements, 0 meaning 3.
+	Depth int
+	// Children limits the number of children of every element, 0 meaning 4.
+	Children int
+	// Invalid is the probability of the document ending with an illegal construct.
+	Invalid float64
+}
+
+// Document is a generated template and the tokens it tokenizes to, excluding EOF. For invalid documents the tokens
+// end with the Illegal token, since tokens after it depend on how the tokenizer recovers.
+type Document struct {
+	Source string
+	Tokens []html.Token
+}
+
+// Generate returns a random document. Element names are lowercase or occasionally uppercase, attribute values use
+// either quote and text never contains a `<` starting a tag.
+func Generate(r *rand.Rand, opts Options) Document {
+	if opts.Depth == 0 {
+		opts.Depth = 3
+	}
+	if opts.Children == 0 {
+		opts.Children = 4
+	}
+
+	g := &generator{r: r, opts: opts, location: html.Location{Line: 1, Column: 1}}
+	if r.IntN(2) == 0 {
+		g.tokens = append(g.tokens, &html.Doctype{Location: g.location})
+		g.write("<!DOCTYPE html>")
+		g.text()
+	}
+	g.children(0)
+
+	if r.Float64() < opts.Invalid {
+		g.illegal()
+	}
+	return Document{g.source.String(), g.tokens}
+}
+
+type generator struct {
+	r        *rand.Rand
+	opts     Options
+	source   strings.Builder
+	location html.Location
+	tokens   []html.Token
+}
+
+func (g *generator) write(s string) {
+	g.source.WriteString(s)
+	for _, c := range s {
+		g.location.Cursor++
+		g.location.Column++
+		if c == '\n' {
+			g.location.Line++
+			g.location.Column = 1
+		}
+	}
+}
+
+func (g *generator) last() html.Token {
+	if len(g.tokens) == 0 {
+		return nil
+	}
+	return g.tokens[len(g.tokens)-1]
+}
+
+func (g *generator) pick(values []string) string {
+	return values[g.r.IntN(len(values))]
+}
+
+func (g *generator) children(depth int) {
+	for range g.r.IntN(g.opts.Children + 1) {
+		switch n := g.r.IntN(10); {
+		case n < 3:
+			g.text()
+		case n < 5 || depth >= g.opts.Depth:
+			g.void()
+		case n < 6:
+			g.rawText()
+		default:
+			name := g.name(containerElements)
+			g.startTag(name, false)
+			g.children(depth + 1)
+			g.endTag(name)
+		}
+	}
+}
+
+// name returns one of the names, uppercasing it now and then since tag names are case-insensitive.
+func (g *generator) name(names []string) string {
+	name := g.pick(names)
+	if g.r.IntN(8) == 0 {
+		return strings.ToUpper(name)
+	}
+	return name
+}
+
+func (g *generator) text() {
+	var b strings.Builder
+	for i := range 1 + g.r.IntN(5) {
+		if i > 0 {
+			b.WriteString([]string{" ", " ", "\n", "\t"}[g.r.IntN(4)])
+		}
+		b.WriteString(g.pick(words))
+	}
+
+	// Text right after text continues the same token, raw text never being followed by text.
+	if previous, ok := g.last().(*html.Text); ok {
+		previous.Value += b.String()
+	} else {
+		g.tokens = append(g.tokens, &html.Text{Value: b.String(), Location: g.location})
+	}
+	g.write(b.String())
+}
+
+func (g *generator) void() {
+	g.startTag(g.name(voidElements), g.r.IntN(2) == 0)
+}
+
+func (g *generator) rawText() {
+	name := g.name(rawTextElements)
+	g.startTag(name, false)
+	if content := []string{"", "if (a < b && c > d) {}", "<p>not a tag</p>", "</" + name + "x>"}[g.r.IntN(4)]; content != "" {
+		g.tokens = append(g.tokens, &html.Text{Value: content, Location: g.location})
+		g.write(content)
+	}
+	g.endTag(name)
+}
+
+func (g *generator) startTag(name string, selfClosing bool) {
+	tag := &html.StartTag{
+		Name:          name,
+		Atom:          atom.Lookup([]byte(strings.ToLower(name))),
+		Attributes:    map[string]html.Attribute{},
+		IsSelfClosing: selfClosing,
+		Location:      g.location,
+	}
+	g.tokens = append(g.tokens, tag)
+	g.write("<" + name)
+
+	bare := false
+	for range g.r.IntN(4) {
+		g.write([]string{" ", " ", "\n  ", "  "}[g.r.IntN(4)])
+
+		attribute := html.Attribute{Name: g.pick(attributeNames), NameLocation: g.location}
+		attribute.Atom = atom.Lookup([]byte(attribute.Name))
+		g.write(attribute.Name)
+		attribute.NameEnd = g.location
+
+		if attribute.Name != "hidden" && attribute.Name != "disabled" {
+			quote := g.pick([]string{`"`, `'`})
+			attribute.EqualsLocation = g.location
+			g.write("=")
+			attribute.ValueLocation, attribute.Quote = g.location, rune(quote[0])
+			attribute.Value = strings.ReplaceAll(g.pick([]string{"main", "a b", "x > y", "it's", `say "hi"`, "/path?q=1&r=2", ""}), quote, "")
+			g.write(quote + attribute.Value + quote)
+			attribute.ValueEnd = g.location
+		}
+		// The tokenizer keeps the first of duplicate attributes.
+		if _, ok := tag.Attributes[attribute.Name]; !ok {
+			tag.Attributes[attribute.Name] = attribute
+		}
+		bare = attribute.ValueLocation == html.Location{}
+	}
+
+	// The tokenizer rejects a slash right after an attribute name as an unexpected character in the name.
+	if selfClosing && bare {
+		g.write(" /")
+	} else if selfClosing {
+		g.write(g.pick([]string{"/", " /"}))
+	}
+	g.write(">")
+}
+
+func (g *generator) endTag(name string) {
+	g.tokens = append(g.tokens, &html.EndTag{Name: name, Atom: atom.Lookup([]byte(strings.ToLower(name))), Location: g.location})
+	g.write("</" + name + ">")
+}
+
+// illegal appends one of the constructs the tokenizer rejects.
+func (g *generator) illegal() {
+	switch g.r.IntN(3) {
+	case 0:
+		g.tokens = append(g.tokens, &html.Illegal{Reason: "comments and markup declarations are not supported", Code: html.CodeMarkupDeclaration, Location: g.location})
+		g.write("<!-- comment -->")
+	case 1:
+		g.write("<div id=")
+		g.tokens = append(g.tokens, &html.Illegal{Reason: "expected quotes in attribute definition", Code: html.CodeUnquotedAttributeValue, Location: g.location})
+		g.write("unquoted>")
+	default:
+		g.write(`<div title="unterminated`)
+		g.tokens = append(g.tokens, &html.Illegal{Reason: "expected closing quote", Code: html.CodeUnterminatedAttributeValue, Location: g.location})
+	}
+}
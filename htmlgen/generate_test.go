@@ -0,0 +1,27 @@
+package htmlgen
+
+import (
+	"math/rand/v2"
+	"reflect"
+	"testing"
+
+	"github.com/terawatthour/html"
+)
+
+func TestGenerate(t *testing.T) {
+	for seed := range uint64(500) {
+		document := Generate(rand.New(rand.NewPCG(seed, seed)), Options{Invalid: 0.3})
+
+		var tokens []html.Token
+		for token := range html.Tokenize(document.Source) {
+			tokens = append(tokens, token)
+			if token.Kind() == "ILLEGAL" {
+				break
+			}
+		}
+
+		if !reflect.DeepEqual(tokens, document.Tokens) {
+			t.Fatalf("seed %d: tokens of %q differ from the generated ones", seed, document.Source)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package html
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type Image struct {
+	// Tag is either `img` or `source` for sources nested in `<picture>`.
+	Tag        string
+	Src        string
+	Alt        string
+	Sizes      string
+	Media      string
+	Type       string
+	Candidates []ImageCandidate
+	Location
+}
+
+type ImageCandidate struct {
+	URL string
+	// Resolved is URL resolved against the base URL, nil if URL is not a valid URL.
+	Resolved *url.URL
+	// Width is the value of the `w` descriptor, 0 if absent.
+	Width int
+	// Density is the value of the `x` descriptor, 0 if absent.
+	Density float64
+	// Height is the value of the `h` descriptor, 0 if absent. It is only allowed along with a width descriptor.
+	Height int
+}
+
+// ExtractImages returns all `<img>` elements and `<source>` elements of `<picture>`, with their `srcset` candidates
+// resolved against base, which may be nil. The `src` attribute of an `<img>` is reported as the last candidate
+// unless the `srcset` already declares a 1x density or uses width descriptors. The first `<base href>` in the document
+// takes precedence over base, as in browsers, including for the images before it.
+func ExtractImages(tokens iter.Seq[Token], base *url.URL) ([]Image, error) {
+	var images []Image
+	pictures := 0
+
+	all := slices.Collect(tokens)
+	base = BaseURL(slices.Values(all), base)
+	for _, token := range all {
+		switch token := token.(type) {
+		case *Illegal:
+			if token.Code != CodeMarkupDeclaration {
+				return images, token
+			}
+		case *EndTag:
+			if strings.EqualFold(token.Name, "picture") {
+				pictures = max(pictures-1, 0)
+			}
+		case *StartTag:
+			name := strings.ToLower(token.Name)
+			switch {
+			case name == "picture" && !token.IsSelfClosing:
+				pictures++
+				continue
+			case name == "img", name == "source" && pictures > 0:
+			default:
+				continue
+			}
+
+			value := func(name string) string {
+				attribute, _ := token.GetAttribute(name)
+				return attribute.Value
+			}
+			image := Image{
+				Tag:      name,
+				Src:      value("src"),
+				Alt:      value("alt"),
+				Sizes:    value("sizes"),
+				Media:    value("media"),
+				Type:     value("type"),
+				Location: token.Location,
+			}
+			image.Candidates, _ = ParseSrcset(value("srcset"))
+
+			if name == "img" && strings.TrimFunc(image.Src, isWhitespace) != "" && !hasDefaultDensity(image.Candidates) {
+				image.Candidates = append(image.Candidates, ImageCandidate{URL: strings.TrimFunc(image.Src, isWhitespace), Density: 1})
+			}
+
+			for i := range image.Candidates {
+				image.Candidates[i].Resolved, _ = ResolveURL(base, image.Candidates[i].URL)
+			}
+
+			images = append(images, image)
+		}
+	}
+
+	return images, nil
+}
+
+func hasDefaultDensity(candidates []ImageCandidate) bool {
+	for _, candidate := range candidates {
+		if candidate.Width > 0 || candidate.Density == 1 || candidate.Density == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseSrcset parses a `srcset` attribute following the image candidate microsyntax of the HTML standard.
+// Candidates with invalid descriptors are dropped and reported in the joined error, while the valid ones are still
+// returned. The URLs of the candidates are left unresolved.
+// https://html.spec.whatwg.org/multipage/images.html#parsing-a-srcset-attribute
+func ParseSrcset(value string) ([]ImageCandidate, error) {
+	var candidates []ImageCandidate
+	var errs []error
+
+	input := []rune(value)
+	i := 0
+	for {
+		for i < len(input) && (isWhitespace(input[i]) || input[i] == ',') {
+			i++
+		}
+		if i >= len(input) {
+			break
+		}
+
+		start := i
+		for i < len(input) && !isWhitespace(input[i]) {
+			i++
+		}
+		candidate := ImageCandidate{URL: string(input[start:i])}
+
+		var descriptors []string
+		if trimmed := strings.TrimRight(candidate.URL, ","); trimmed != candidate.URL {
+			candidate.URL = trimmed
+		} else {
+			descriptors, i = srcsetDescriptors(input, i)
+		}
+
+		if err := candidate.applyDescriptors(descriptors); err != nil {
+			errs = append(errs, fmt.Errorf("candidate %q: %w", candidate.URL, err))
+			continue
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	return candidates, errors.Join(errs...)
+}
+
+func srcsetDescriptors(input []rune, i int) ([]string, int) {
+	var descriptors []string
+	var current []rune
+	inParens := false
+
+	for ; i < len(input); i++ {
+		c := input[i]
+		switch {
+		case inParens:
+			current = append(current, c)
+			inParens = c != ')'
+		case c == ',':
+			if len(current) > 0 {
+				descriptors = append(descriptors, string(current))
+			}
+			return descriptors, i + 1
+		case isWhitespace(c):
+			if len(current) > 0 {
+				descriptors = append(descriptors, string(current))
+				current = nil
+			}
+		default:
+			current = append(current, c)
+			inParens = c == '('
+		}
+	}
+
+	if len(current) > 0 {
+		descriptors = append(descriptors, string(current))
+	}
+	return descriptors, i
+}
+
+func (c *ImageCandidate) applyDescriptors(descriptors []string) error {
+	for _, descriptor := range descriptors {
+		value, suffix := descriptor[:len(descriptor)-1], descriptor[len(descriptor)-1]
+		switch suffix {
+		case 'w':
+			width, err := strconv.Atoi(value)
+			if c.Width != 0 || c.Density != 0 || err != nil || width <= 0 || strings.HasPrefix(value, "+") {
+				return fmt.Errorf("invalid width descriptor %q", descriptor)
+			}
+			c.Width = width
+		case 'x':
+			density, err := strconv.ParseFloat(value, 64)
+			if c.Width != 0 || c.Density != 0 || c.Height != 0 || err != nil || density < 0 || strings.HasPrefix(value, "+") {
+				return fmt.Errorf("invalid density descriptor %q", descriptor)
+			}
+			c.Density = density
+		case 'h':
+			height, err := strconv.Atoi(value)
+			if c.Height != 0 || c.Density != 0 || err != nil || height <= 0 || strings.HasPrefix(value, "+") {
+				return fmt.Errorf("invalid height descriptor %q", descriptor)
+			}
+			c.Height = height
+		default:
+			return fmt.Errorf("unknown descriptor %q", descriptor)
+		}
+	}
+
+	if c.Height != 0 && c.Width == 0 {
+		return errors.New("height descriptor without a width descriptor")
+	}
+	return nil
+}
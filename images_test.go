@@ -0,0 +1,84 @@
+package html
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExtractImages(t *testing.T) {
+	template := `<picture>
+	<source srcset="hero.avif 1x, hero@2x.avif 2x" type="image/avif">
+	<img SRC="hero.jpg" SrcSet="hero-480.jpg 480w, hero-960.jpg 960w" sizes="(max-width: 600px) 480px, 960px" ALT="Hero">
+</picture>
+<video><source src="clip.mp4"></video>
+<img src="/logo.png" srcset="/logo@2x.png 2x" alt=""><!-- end -->`
+
+	base, _ := url.Parse("https://example.com/a/")
+	images, err := ExtractImages(Tokenize(template), base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(images) != 3 {
+		t.Fatalf("expected 3 images, got %d", len(images))
+	}
+
+	expected := []struct {
+		tag        string
+		candidates []ImageCandidate
+	}{
+		{"source", []ImageCandidate{{URL: "hero.avif", Density: 1}, {URL: "hero@2x.avif", Density: 2}}},
+		{"img", []ImageCandidate{{URL: "hero-480.jpg", Width: 480}, {URL: "hero-960.jpg", Width: 960}}},
+		{"img", []ImageCandidate{{URL: "/logo@2x.png", Density: 2}, {URL: "/logo.png", Density: 1}}},
+	}
+
+	for i, image := range images {
+		if image.Tag != expected[i].tag || len(image.Candidates) != len(expected[i].candidates) {
+			t.Fatalf("image %d: expected %+v, got %+v", i, expected[i], image)
+		}
+		for j, candidate := range image.Candidates {
+			want := expected[i].candidates[j]
+			if candidate.URL != want.URL || candidate.Width != want.Width || candidate.Density != want.Density {
+				t.Errorf("image %d candidate %d: expected %+v, got %+v", i, j, want, candidate)
+			}
+		}
+	}
+
+	if got := images[0].Candidates[1].Resolved.String(); got != "https://example.com/a/hero@2x.avif" {
+		t.Errorf("unexpected resolved URL %q", got)
+	}
+	if images[1].Sizes != "(max-width: 600px) 480px, 960px" || images[1].Alt != "Hero" {
+		t.Errorf("unexpected attributes: %+v", images[1])
+	}
+}
+
+func TestExtractImagesBeforeBase(t *testing.T) {
+	images, err := ExtractImages(Tokenize(`<img src="logo.png"><base href="/assets/">`), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(images) != 1 || images[0].Candidates[0].Resolved.String() != "/assets/logo.png" {
+		t.Errorf("expected the base to apply to images before it, got %+v", images)
+	}
+}
+
+func TestParseSrcset(t *testing.T) {
+	candidates, err := ParseSrcset(" a.png 1x,b.png  2.5x , c.png 300w 200h,d.png, data:image/png;base64,AAA=, e.png 3x 2x, f.png 10h")
+
+	expected := []ImageCandidate{
+		{URL: "a.png", Density: 1},
+		{URL: "b.png", Density: 2.5},
+		{URL: "c.png", Width: 300, Height: 200},
+		{URL: "d.png"},
+		{URL: "data:image/png;base64,AAA="},
+	}
+	if !reflect.DeepEqual(candidates, expected) {
+		t.Errorf("got %+v, expected %+v", candidates, expected)
+	}
+
+	if err == nil || !strings.Contains(err.Error(), `candidate "e.png": invalid density descriptor "2x"`) ||
+		!strings.Contains(err.Error(), `candidate "f.png": height descriptor without a width descriptor`) {
+		t.Errorf("unexpected error %v", err)
+	}
+}
@@ -0,0 +1,184 @@
+package html
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// Edit replaces Removed runes of the template starting at the rune Offset with Inserted.
+type Edit struct {
+	Offset   int
+	Removed  int
+	Inserted string
+}
+
+// Document holds the tokens of a template along with the tokenizer state before each of them, so that an edit
+// re-tokenizes only the region around it and shifts the locations of the tokens after it.
+type Document struct {
+	start   Tokenizer
+	entries []chunkEntry
+}
+
+// Document tokenizes the remaining input, returning it as a Document to which edits can be applied.
+func (t *Tokenizer) Document() *Document {
+	document := &Document{start: *t}
+	document.entries = t.chunk(len(t.template) + 1)
+	return document
+}
+
+// Tokens returns the tokens of the document, excluding EOF.
+func (d *Document) Tokens() []Token {
+	tokens := make([]Token, len(d.entries))
+	for i, entry := range d.entries {
+		tokens[i] = entry.token
+	}
+	return tokens
+}
+
+// Source returns the current template of the document.
+func (d *Document) Source() string {
+	return string(d.start.template)
+}
+
+// Apply edits the template, re-tokenizing from the token before the one containing the edit, since where a token ends
+// may depend on the character after it, until the tokenizer state matches that of an old token past the edit. Tokens
+// from there on are kept with their locations shifted.
+func (d *Document) Apply(edit Edit) error {
+	if edit.Offset < d.start.i || edit.Removed < 0 || edit.Offset+edit.Removed > len(d.start.template) {
+		return fmt.Errorf("edit of %d runes at %d is out of range", edit.Removed, edit.Offset)
+	}
+
+	inserted := []rune(edit.Inserted)
+	template := slices.Concat(d.start.template[:edit.Offset], inserted, d.start.template[edit.Offset+edit.Removed:])
+	delta := len(inserted) - edit.Removed
+
+	containing := slices.IndexFunc(d.entries, func(entry chunkEntry) bool { return entry.after.i > edit.Offset })
+	if containing < 0 {
+		containing = len(d.entries)
+	}
+	restart := max(containing-1, 0)
+
+	tokenizer := d.start
+	if restart < len(d.entries) {
+		tokenizer = d.entries[restart].before
+	}
+	tokenizer.template = template
+	d.start.template = template
+
+	entries := slices.Clip(d.entries[:restart])
+	old := d.entries[restart:]
+	for {
+		if tokenizer.i >= edit.Offset+len(inserted) {
+			j, found := slices.BinarySearchFunc(old, tokenizer.i-delta, func(entry chunkEntry, start int) int {
+				return entry.start - start
+			})
+			if found && old[j].start >= edit.Offset+edit.Removed && slices.Equal(old[j].before.rawText, tokenizer.rawText) {
+				shift := locationShift{old[j].before.location(), tokenizer.location()}
+				for _, entry := range old[j:] {
+					entries = append(entries, shift.entry(entry, template))
+				}
+				break
+			}
+		}
+
+		before := tokenizer
+		token := tokenizer.next()
+		if token.Kind() == "EOF" {
+			break
+		}
+		entries = append(entries, chunkEntry{token, before.i, before, tokenizer})
+	}
+
+	d.entries = entries
+	return nil
+}
+
+// locationShift moves locations after an edit, from where they were in the old template to the new one.
+type locationShift struct {
+	from, to Location
+}
+
+func (s locationShift) location(location Location) Location {
+	if location.Line == s.from.Line {
+		location.Column += s.to.Column - s.from.Column
+	}
+	location.Line += s.to.Line - s.from.Line
+	location.Cursor += s.to.Cursor - s.from.Cursor
+	return location
+}
+
+// tokenizer moves the tokenizer state, forgetting where a raw text scan could resume as the text after it changed.
+func (s locationShift) tokenizer(tokenizer Tokenizer, template []rune) Tokenizer {
+	location := s.location(tokenizer.location())
+	tokenizer.template, tokenizer.i, tokenizer.line, tokenizer.column = template, location.Cursor, location.Line, location.Column
+	tokenizer.rawTextScanned = Location{}
+	return tokenizer
+}
+
+func (s locationShift) entry(entry chunkEntry, template []rune) chunkEntry {
+	entry.token = s.token(entry.token, template)
+	entry.start += s.to.Cursor - s.from.Cursor
+	entry.before = s.tokenizer(entry.before, template)
+	entry.after = s.tokenizer(entry.after, template)
+	return entry
+}
+
+// token returns a copy of the token with its locations shifted, leaving the original untouched.
+func (s locationShift) token(token Token, template []rune) Token {
+	switch token := token.(type) {
+	case *Doctype:
+		shifted := *token
+		shifted.Location = s.location(token.Location)
+		return &shifted
+	case *StartTag:
+		shifted := *token
+		shifted.Location = s.location(token.Location)
+		shifted.Expressions = s.expressions(token.Expressions)
+		if token.Attributes != nil {
+			shifted.Attributes = maps.Clone(token.Attributes)
+			for name, attribute := range shifted.Attributes {
+				attribute.NameLocation, attribute.NameEnd = s.location(attribute.NameLocation), s.location(attribute.NameEnd)
+				attribute.EqualsLocation = s.location(attribute.EqualsLocation)
+				attribute.ValueLocation, attribute.ValueEnd = s.location(attribute.ValueLocation), s.location(attribute.ValueEnd)
+				attribute.Expressions = s.expressions(attribute.Expressions)
+				shifted.Attributes[name] = attribute
+			}
+		}
+		if token.lazy != nil {
+			shifted.lazy = &lazyAttributes{
+				tokenizer: s.tokenizer(token.lazy.tokenizer, template),
+				end:       token.lazy.end + s.to.Cursor - s.from.Cursor,
+			}
+		}
+		return &shifted
+	case *EndTag:
+		shifted := *token
+		shifted.Location = s.location(token.Location)
+		return &shifted
+	case *Text:
+		shifted := *token
+		shifted.Location = s.location(token.Location)
+		return &shifted
+	case *Expression:
+		shifted := *token
+		shifted.Location = s.location(token.Location)
+		return &shifted
+	case *Illegal:
+		shifted := *token
+		shifted.Location = s.location(token.Location)
+		return &shifted
+	}
+	return token
+}
+
+func (s locationShift) expressions(expressions []*Expression) []*Expression {
+	if expressions == nil {
+		return nil
+	}
+	shifted := make([]*Expression, len(expressions))
+	for i, expression := range expressions {
+		shifted[i] = s.token(expression, nil).(*Expression)
+	}
+	return shifted
+}
@@ -0,0 +1,81 @@
+package html
+
+import (
+	"math/rand/v2"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDocumentApply(t *testing.T) {
+	template := strings.Repeat(`<div class="item" title="a < b">Hello <b>world</b>
+<script>if (a <b) { document.write("<p>x</p>") }</script>
+<p id='x'>{{ name }} <br/> more</p>
+`, 10)
+
+	cases := []Edit{
+		{Offset: 0, Removed: 0, Inserted: "<!DOCTYPE html>\n"},
+		{Offset: 5, Removed: 0, Inserted: " id=\"first\""},
+		{Offset: 40, Removed: 3, Inserted: "\n\n"},
+		{Offset: 60, Removed: 0, Inserted: "</script>"},
+		{Offset: 61, Removed: 0, Inserted: "<style>"},
+		{Offset: 100, Removed: 50, Inserted: ""},
+		{Offset: len([]rune(template)), Removed: 0, Inserted: "trailing <i>text</i>"},
+	}
+
+	random := rand.New(rand.NewPCG(1, 2))
+	for range 100 {
+		offset := random.IntN(len(template))
+		removed := random.IntN(min(10, len(template)-offset) + 1)
+		inserted := []string{"", "<", ">", "\n", "<a href='x'>", "</a>", "<script>", "</script>", "\"", "{{", "}}", "text"}[random.IntN(12)]
+		cases = append(cases, Edit{offset, removed, inserted})
+	}
+
+	applyEdits(t, template, cases)
+
+	// Deleting ahead of raw text moves the tokenizer states after it back, past where their last raw text scan ended.
+	applyEdits(t, "<p>abcdefghijklmnopqrstuvwxyz</p><style>a</style><style>xx</style>yyy", []Edit{
+		{Offset: 3, Removed: 26, Inserted: ""},
+		{Offset: len("<p></p><style>a</style><style>"), Removed: 0, Inserted: "q"},
+	})
+}
+
+// applyEdits applies the edits one after another to a document, checking its tokens against a fresh tokenization.
+func applyEdits(t *testing.T, template string, edits []Edit) {
+	t.Helper()
+	tokenizer := NewTokenizer(template)
+	tokenizer.EnableExpressions(DefaultDelimiters)
+	document := tokenizer.Document()
+	source := []rune(template)
+
+	for _, edit := range edits {
+		edit.Offset = min(edit.Offset, len(source))
+		edit.Removed = min(edit.Removed, len(source)-edit.Offset)
+		if err := document.Apply(edit); err != nil {
+			t.Fatal(err)
+		}
+		source = append(source[:edit.Offset:edit.Offset], append([]rune(edit.Inserted), source[edit.Offset+edit.Removed:]...)...)
+
+		if document.Source() != string(source) {
+			t.Fatalf("after %+v: source differs", edit)
+		}
+
+		expected := NewTokenizer(string(source))
+		expected.EnableExpressions(DefaultDelimiters)
+		var tokens []Token
+		for token := range expected.All() {
+			tokens = append(tokens, token)
+		}
+		if got := document.Tokens(); !reflect.DeepEqual(got, tokens) {
+			t.Fatalf("after %+v: got %d tokens, expected %d", edit, len(got), len(tokens))
+		}
+	}
+}
+
+func TestDocumentApplyOutOfRange(t *testing.T) {
+	tokenizer := NewTokenizer("<p>text</p>")
+	document := tokenizer.Document()
+	if err := document.Apply(Edit{Offset: 8, Removed: 4}); err == nil {
+		t.Error("expected an error for an edit past the end")
+	}
+}
@@ -0,0 +1,63 @@
+package html
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestInjectIntegrity(t *testing.T) {
+	template := `<script src="/app.js"></script><LINK REL="preload stylesheet" HREF="/app.css"><script src="/pinned.js" INTEGRITY="sha256-abc"></script><link rel="icon" href="/favicon.ico"><script src="/missing.js"></script>`
+
+	resolve := IntegrityFromContent(func(url string) ([]byte, error) {
+		if url == "/missing.js" {
+			return nil, errors.New("not found")
+		}
+		return []byte(url), nil
+	})
+
+	var tags []*StartTag
+	var illegal []*Illegal
+	for token := range InjectIntegrity(Tokenize(template), resolve) {
+		switch token := token.(type) {
+		case *StartTag:
+			tags = append(tags, token)
+		case *Illegal:
+			illegal = append(illegal, token)
+		}
+	}
+
+	if len(tags) != 4 || len(illegal) != 1 {
+		t.Fatalf("expected 4 start tags and 1 illegal token, got %d and %d", len(tags), len(illegal))
+	}
+
+	if got := tags[0].Attributes["integrity"].Value; got != "sha384-SBKGsO1RYQBiP4pa8guyq5mLEnyuiNdInMR/0G/0VfsPzRb4fV66tYDb6sKDFKyV" {
+		t.Errorf("unexpected integrity for script: %q", got)
+	}
+	if got := tags[0].Attributes["crossorigin"].Value; got != "anonymous" {
+		t.Errorf("expected crossorigin to be injected, got %q", got)
+	}
+	if !tags[1].HasAttribute("integrity") {
+		t.Errorf("expected integrity on stylesheet link")
+	}
+	if integrity, _ := tags[2].GetAttribute("integrity"); len(tags[2].Attributes) != 2 || integrity.Value != "sha256-abc" {
+		t.Errorf("existing integrity must be preserved, got %v", tags[2].Attributes)
+	}
+	if _, ok := tags[3].Attributes["integrity"]; ok {
+		t.Errorf("non-stylesheet links must not receive integrity")
+	}
+	if illegal[0].Reason != "not found" {
+		t.Errorf("unexpected illegal reason: %q", illegal[0].Reason)
+	}
+}
+
+func TestInjectIntegrityLazyAttributes(t *testing.T) {
+	tokenizer := NewTokenizer(`<script src="/app.js"></script>`)
+	tokenizer.EnableLazyAttributes()
+	resolve := IntegrityFromContent(func(url string) ([]byte, error) { return []byte(url), nil })
+
+	for token := range InjectIntegrity(tokenizer.All(), resolve) {
+		if tag, ok := token.(*StartTag); ok && !tag.HasAttribute("integrity") {
+			t.Errorf("expected integrity on a tag with lazy attributes, got %v", tag)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package html
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// UnmarshalToken decodes a token encoded by one of the token MarshalJSON methods, using its `kind` to pick the type.
+func UnmarshalToken(data []byte) (Token, error) {
+	var header struct {
+		Kind string `json:"kind"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, err
+	}
+
+	var token Token
+	switch header.Kind {
+	case "DOCTYPE":
+		token = &Doctype{}
+	case "START_TAG":
+		token = &StartTag{}
+	case "END_TAG":
+		token = &EndTag{}
+	case "TEXT":
+		token = &Text{}
+	case "EXPRESSION":
+		token = &Expression{}
+	case "ILLEGAL":
+		token = &Illegal{}
+	case "EOF":
+		token = &Eof{}
+	default:
+		return nil, fmt.Errorf("unknown token kind %q", header.Kind)
+	}
+
+	if err := json.Unmarshal(data, token); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// marshalToken encodes the token's fields, prefixed by its kind.
+func marshalToken(kind string, token any) ([]byte, error) {
+	fields, err := json.Marshal(token)
+	if err != nil {
+		return nil, err
+	}
+
+	data := fmt.Appendf(nil, `{"kind":%q`, kind)
+	if len(fields) > 2 {
+		data = append(data, ',')
+	}
+	return append(data, fields[1:]...), nil
+}
+
+func (t *Doctype) MarshalJSON() ([]byte, error) {
+	type doctype Doctype
+	return marshalToken(t.Kind(), (*doctype)(t))
+}
+
+func (t *Doctype) UnmarshalJSON(data []byte) error {
+	type doctype Doctype
+	return json.Unmarshal(data, (*doctype)(t))
+}
+
+func (t *StartTag) MarshalJSON() ([]byte, error) {
+	type startTag StartTag
+	return marshalToken(t.Kind(), (*startTag)(t))
+}
+
+func (t *StartTag) UnmarshalJSON(data []byte) error {
+	type startTag StartTag
+	if err := json.Unmarshal(data, (*startTag)(t)); err != nil {
+		return err
+	}
+
+	t.Atom, _ = intern([]rune(t.Name))
+	for name, attribute := range t.Attributes {
+		attribute.Atom, _ = intern([]rune(attribute.Name))
+		t.Attributes[name] = attribute
+	}
+	return nil
+}
+
+func (t *EndTag) MarshalJSON() ([]byte, error) {
+	type endTag EndTag
+	return marshalToken(t.Kind(), (*endTag)(t))
+}
+
+func (t *EndTag) UnmarshalJSON(data []byte) error {
+	type endTag EndTag
+	if err := json.Unmarshal(data, (*endTag)(t)); err != nil {
+		return err
+	}
+
+	t.Atom, _ = intern([]rune(t.Name))
+	return nil
+}
+
+func (t *Text) MarshalJSON() ([]byte, error) {
+	type text Text
+	return marshalToken(t.Kind(), (*text)(t))
+}
+
+func (t *Text) UnmarshalJSON(data []byte) error {
+	type text Text
+	return json.Unmarshal(data, (*text)(t))
+}
+
+func (t *Expression) MarshalJSON() ([]byte, error) {
+	type expression Expression
+	return marshalToken(t.Kind(), (*expression)(t))
+}
+
+func (t *Expression) UnmarshalJSON(data []byte) error {
+	type expression Expression
+	return json.Unmarshal(data, (*expression)(t))
+}
+
+func (t *Illegal) MarshalJSON() ([]byte, error) {
+	type illegal Illegal
+	return marshalToken(t.Kind(), (*illegal)(t))
+}
+
+func (t *Illegal) UnmarshalJSON(data []byte) error {
+	type illegal Illegal
+	return json.Unmarshal(data, (*illegal)(t))
+}
+
+func (t *Eof) MarshalJSON() ([]byte, error) {
+	type eof Eof
+	return marshalToken(t.Kind(), (*eof)(t))
+}
+
+func (t *Eof) UnmarshalJSON(data []byte) error {
+	type eof Eof
+	return json.Unmarshal(data, (*eof)(t))
+}
@@ -0,0 +1,39 @@
+package html
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestTokenJSON(t *testing.T) {
+	data, err := json.Marshal(&EndTag{Name: "div", Location: Location{Line: 1, Column: 12, Cursor: 11}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `{"kind":"END_TAG","name":"div","line":1,"column":12,"cursor":11}`; string(data) != expected {
+		t.Errorf("expected %s, got %s", expected, data)
+	}
+
+	tokenizer := NewTokenizer(`<!DOCTYPE html><p class="a {{ b }}">Hello {{ name }}</p>`)
+	tokenizer.EnableExpressions(DefaultDelimiters)
+
+	for token := range tokenizer.All() {
+		data, err := json.Marshal(token)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		decoded, err := UnmarshalToken(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(token, decoded) {
+			t.Errorf("round trip mismatch: expected %#v, got %#v", token, decoded)
+		}
+	}
+
+	if _, err := UnmarshalToken([]byte(`{"kind":"COMMENT"}`)); err == nil {
+		t.Error("expected an error for an unknown kind")
+	}
+}
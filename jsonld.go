@@ -0,0 +1,184 @@
+package html
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// JSONLD is the contents of a `<script type="application/ld+json">` block.
+type JSONLD struct {
+	Raw string
+	// Location of the declaring script tag.
+	Location
+}
+
+// ExtractJSONLD returns the JSON-LD blocks found in the token stream. HTML comment and CDATA wrappers
+// around the script text are removed.
+func ExtractJSONLD(tokens iter.Seq[Token]) ([]JSONLD, error) {
+	var blocks []JSONLD
+	var current *JSONLD
+
+	for token := range tokens {
+		switch token := token.(type) {
+		case *Illegal:
+			if token.Code != CodeMarkupDeclaration {
+				return blocks, token
+			}
+		case *StartTag:
+			current = nil
+			kind, _ := token.GetAttribute("type")
+			if strings.EqualFold(token.Name, "script") && strings.EqualFold(strings.TrimSpace(kind.Value), "application/ld+json") {
+				current = &JSONLD{Location: token.Location}
+			}
+		case *Text:
+			if current != nil {
+				current.Raw += token.Value
+			}
+		case *EndTag:
+			if current != nil && strings.EqualFold(token.Name, "script") {
+				if current.Raw = unwrapScriptText(current.Raw); current.Raw != "" {
+					blocks = append(blocks, *current)
+				}
+			}
+			current = nil
+		}
+	}
+
+	return blocks, nil
+}
+
+// Decode returns the top-level objects of the block. A top-level array yields each of its elements.
+func (j JSONLD) Decode() ([]map[string]any, error) {
+	var value any
+	if err := json.Unmarshal([]byte(j.Raw), &value); err != nil {
+		return nil, err
+	}
+
+	switch value := value.(type) {
+	case map[string]any:
+		return []map[string]any{value}, nil
+	case []any:
+		objects := make([]map[string]any, 0, len(value))
+		for _, element := range value {
+			object, ok := element.(map[string]any)
+			if !ok {
+				return nil, errors.New("expected JSON-LD array elements to be objects")
+			}
+			objects = append(objects, object)
+		}
+		return objects, nil
+	}
+
+	return nil, errors.New("expected JSON-LD object or array")
+}
+
+func unwrapScriptText(text string) string {
+	text = strings.TrimSpace(text)
+	for _, wrapper := range [][2]string{{"<!--", "-->"}, {"//<![CDATA[", "//]]>"}, {"<![CDATA[", "]]>"}} {
+		if strings.HasPrefix(text, wrapper[0]) {
+			text = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(text, wrapper[0]), wrapper[1]))
+		}
+	}
+	return text
+}
+
+// requiredProperties lists the properties schema.org types need for search engines to use them, each entry being
+// a set of alternatives of which at least one must be present.
+var requiredProperties = map[string][][]string{
+	"Article":        {{"headline"}, {"author"}, {"datePublished"}},
+	"NewsArticle":    {{"headline"}, {"author"}, {"datePublished"}},
+	"BlogPosting":    {{"headline"}, {"author"}, {"datePublished"}},
+	"Product":        {{"name"}, {"offers", "review", "aggregateRating"}},
+	"BreadcrumbList": {{"itemListElement"}},
+	"ListItem":       {{"position"}, {"name", "item"}},
+}
+
+// Validate checks that the objects of the block declaring a common schema.org type have the properties required for
+// it, including the objects of an `@graph` and the items of breadcrumb lists. Diagnostics are located at the
+// declaring script tag, coded "invalid-json-ld" when the block cannot be decoded and "missing-property" otherwise.
+func (j JSONLD) Validate() DiagnosticList {
+	var diagnostics DiagnosticList
+	report := func(code, message string) {
+		diagnostics = append(diagnostics, Diagnostic{
+			Range:    nameRange(j.Location, "script"),
+			Severity: "error",
+			Code:     code,
+			Message:  message,
+		})
+	}
+
+	objects, err := j.Decode()
+	if err != nil {
+		report("invalid-json-ld", "invalid JSON-LD: "+err.Error())
+		return diagnostics
+	}
+
+	var validate func(object map[string]any, path string)
+	validate = func(object map[string]any, path string) {
+		if graph, ok := object["@graph"].([]any); ok {
+			for i, element := range graph {
+				if element, ok := element.(map[string]any); ok {
+					validate(element, fmt.Sprintf("%s@graph[%d] ", path, i))
+				}
+			}
+		}
+
+		for _, kind := range schemaTypes(object["@type"]) {
+			for _, alternatives := range requiredProperties[kind] {
+				if !slices.ContainsFunc(alternatives, func(property string) bool { return object[property] != nil }) {
+					report("missing-property", fmt.Sprintf("%s%s is missing %s", path, kind, quoteAlternatives(alternatives)))
+				}
+			}
+
+			if items, ok := object["itemListElement"].([]any); ok && kind == "BreadcrumbList" {
+				for i, item := range items {
+					if item, ok := item.(map[string]any); ok {
+						validate(item, fmt.Sprintf("%sitemListElement[%d] ", path, i))
+					}
+				}
+			}
+		}
+	}
+
+	for _, object := range objects {
+		validate(object, "")
+	}
+	return diagnostics
+}
+
+// schemaTypes returns the schema.org types of an `@type` value, which is a type or an array of types possibly given
+// as URLs or prefixed names.
+func schemaTypes(value any) []string {
+	var values []any
+	switch value := value.(type) {
+	case string:
+		values = []any{value}
+	case []any:
+		values = value
+	}
+
+	var types []string
+	for _, value := range values {
+		if kind, ok := value.(string); ok {
+			kind = strings.TrimPrefix(strings.TrimPrefix(kind, "schema:"), "https://schema.org/")
+			types = append(types, strings.TrimPrefix(kind, "http://schema.org/"))
+		}
+	}
+	return types
+}
+
+func quoteAlternatives(alternatives []string) string {
+	quoted := make([]string, len(alternatives))
+	for i, alternative := range alternatives {
+		quoted[i] = strconv.Quote(alternative)
+	}
+	if len(quoted) == 1 {
+		return quoted[0]
+	}
+	return strings.Join(quoted[:len(quoted)-1], ", ") + " or " + quoted[len(quoted)-1]
+}
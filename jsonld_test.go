@@ -0,0 +1,80 @@
+package html
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestExtractJSONLD(t *testing.T) {
+	template := `<head>
+<script type="application/ld+json">{"@type": "Article", "headline": "<b>Hello</b>"}</script>
+<script type="text/javascript">var a = 1;</script>
+<script TYPE="APPLICATION/LD+JSON">
+<!--
+[{"@type": "Person"}, {"@type": "Organization"}]
+-->
+</script>
+<script type="application/ld+json">//<![CDATA[
+{"@type": "Product"}
+//]]></script>
+</head><!-- end -->`
+
+	blocks, err := ExtractJSONLD(Tokenize(template))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blocks) != 3 {
+		t.Fatalf("expected 3 blocks, got %d", len(blocks))
+	}
+	if blocks[0].Line != 2 || blocks[0].Raw != `{"@type": "Article", "headline": "<b>Hello</b>"}` {
+		t.Errorf("unexpected first block: %+v", blocks[0])
+	}
+
+	var types []string
+	for _, block := range blocks {
+		objects, err := block.Decode()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, object := range objects {
+			types = append(types, object["@type"].(string))
+		}
+	}
+
+	expected := []string{"Article", "Person", "Organization", "Product"}
+	if !slices.Equal(types, expected) {
+		t.Errorf("expected types %v, got %v", expected, types)
+	}
+}
+
+func TestValidateJSONLD(t *testing.T) {
+	template := `<script type="application/ld+json">{"@type": "Article", "headline": "Hi", "author": {"name": "A"}}</script>
+<script type="application/ld+json">{"@graph": [
+	{"@type": ["Thing", "schema:Product"], "name": "Lamp"},
+	{"@type": "https://schema.org/BreadcrumbList", "itemListElement": [{"@type": "ListItem", "position": 1, "name": "Home"}, {"@type": "ListItem", "item": "/x"}]}
+]}</script>
+<script type="application/ld+json">{"@type": "Product", "name": "Lamp", "offers": {}}</script>
+<script type="application/ld+json">{"@type": </script>`
+
+	blocks, err := ExtractJSONLD(Tokenize(template))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, block := range blocks {
+		for _, diagnostic := range block.Validate() {
+			got = append(got, diagnostic.Code+" "+diagnostic.Error())
+		}
+	}
+
+	expected := []string{
+		`missing-property 1:2: Article is missing "datePublished"`,
+		`missing-property 2:2: @graph[0] Product is missing "offers", "review" or "aggregateRating"`,
+		`missing-property 2:2: @graph[1] itemListElement[1] ListItem is missing "position"`,
+		`invalid-json-ld 7:2: invalid JSON-LD: unexpected end of JSON input`,
+	}
+	if !slices.Equal(got, expected) {
+		t.Errorf("got %q, expected %q", got, expected)
+	}
+}
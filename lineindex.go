@@ -0,0 +1,75 @@
+package html
+
+import (
+	"slices"
+	"unicode/utf8"
+)
+
+// LineIndex maps byte offsets of a template to locations and back, built once so that each conversion is a binary
+// search instead of a scan of the template. Lines end at `\n` and columns count runes, as in tokenizer locations.
+type LineIndex struct {
+	source string
+	// starts holds the byte offset of the start of every line.
+	starts []int
+	// cursors holds the rune offset of the start of every line.
+	cursors []int
+	// ascii tells whether every line is ASCII only, in which case its columns are byte offsets.
+	ascii []bool
+}
+
+// NewLineIndex indexes the lines of the template.
+func NewLineIndex(source string) *LineIndex {
+	index := &LineIndex{source: source, starts: []int{0}, cursors: []int{0}}
+	cursor, ascii := 0, true
+	for i, c := range source {
+		cursor++
+		ascii = ascii && c < utf8.RuneSelf
+		if c == '\n' {
+			index.starts = append(index.starts, i+1)
+			index.cursors = append(index.cursors, cursor)
+			index.ascii = append(index.ascii, ascii)
+			ascii = true
+		}
+	}
+	index.ascii = append(index.ascii, ascii)
+	return index
+}
+
+// Location returns the location of the byte offset, clamped to the template. Offsets within a multi-byte character
+// give its location.
+func (x *LineIndex) Location(offset int) Location {
+	offset = min(max(offset, 0), len(x.source))
+	line, found := slices.BinarySearch(x.starts, offset)
+	if !found {
+		line--
+	}
+
+	column := offset - x.starts[line]
+	if !x.ascii[line] {
+		for offset < len(x.source) && !utf8.RuneStart(x.source[offset]) {
+			offset--
+		}
+		column = utf8.RuneCountInString(x.source[x.starts[line]:offset])
+	}
+	return Location{Line: line + 1, Column: column + 1, Cursor: x.cursors[line] + column}
+}
+
+// Offset returns the byte offset of the location's line and column, clamped to the line. The cursor is ignored.
+func (x *LineIndex) Offset(location Location) int {
+	line := min(max(location.Line, 1), len(x.starts)) - 1
+	end := len(x.source)
+	if line+1 < len(x.starts) {
+		end = x.starts[line+1] - 1
+	}
+
+	column := max(location.Column-1, 0)
+	if x.ascii[line] {
+		return min(x.starts[line]+column, end)
+	}
+	offset := x.starts[line]
+	for ; column > 0 && offset < end; column-- {
+		_, size := utf8.DecodeRuneInString(x.source[offset:end])
+		offset += size
+	}
+	return offset
+}
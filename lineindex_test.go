@@ -0,0 +1,41 @@
+package html
+
+import "testing"
+
+func TestLineIndex(t *testing.T) {
+	source := "<p>\n  日本語 text\n\n<br>"
+	index := NewLineIndex(source)
+
+	// Every rune's location must agree with the tokenizer's way of counting.
+	location := Location{Line: 1, Column: 1}
+	for offset, c := range source {
+		if got := index.Location(offset); got != location {
+			t.Errorf("offset %d: got %+v, expected %+v", offset, got, location)
+		}
+		if got := index.Offset(location); got != offset {
+			t.Errorf("%+v: got offset %d, expected %d", location, got, offset)
+		}
+		location = advanceLocation(location, c)
+	}
+	if got := index.Location(len(source)); got != location {
+		t.Errorf("end: got %+v, expected %+v", got, location)
+	}
+
+	if got := index.Location(7); got != (Location{Line: 2, Column: 3, Cursor: 6}) {
+		t.Errorf("expected offsets within a character to give its location, got %+v", got)
+	}
+	if got := index.Offset(Location{Line: 2, Column: 100}); got != len("<p>\n  日本語 text") {
+		t.Errorf("expected columns to be clamped to the line, got %d", got)
+	}
+	if got := index.Location(-5); got != (Location{Line: 1, Column: 1}) {
+		t.Errorf("expected negative offsets to be clamped, got %+v", got)
+	}
+
+	// Every byte of a multi-byte character, past the second too, gives the character's location.
+	index = NewLineIndex("a€b😀c")
+	for offset, expected := range []int{1, 2, 2, 2, 3, 4, 4, 4, 4, 5} {
+		if got := index.Location(offset); got.Column != expected {
+			t.Errorf("offset %d in \"a€b😀c\": got column %d, expected %d", offset, got.Column, expected)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package html
+
+import "strings"
+
+// LinkedEditingRanges returns the name ranges of a start tag and its matching end tag when the rune offset cursor
+// lies within or right after either name, so that both can be renamed together. It returns nil when the cursor is
+// not on a tag name or the tag has no counterpart, as for void and self-closing elements. End tags match the
+// closest open element of the same name, skipping elements left unclosed.
+func LinkedEditingRanges(template string, cursor int) []Range {
+	type open struct {
+		name  string
+		Range Range
+	}
+
+	var stack []open
+	within := func(r Range) bool {
+		return r.Start.Cursor <= cursor && cursor <= r.End.Cursor
+	}
+
+	t := NewTokenizer(template)
+	for {
+		start := t.location()
+		token := t.next()
+		if token.Kind() == "EOF" || (token.Kind() == "ILLEGAL" && t.i == start.Cursor) {
+			return nil
+		}
+
+		switch token := token.(type) {
+		case *StartTag:
+			if !token.IsSelfClosing && !isVoidElement(token.Name) {
+				stack = append(stack, open{strings.ToLower(token.Name), t.span(t.span(start, 1).End, len([]rune(token.Name)))})
+			}
+		case *EndTag:
+			name := strings.ToLower(token.Name)
+			for i := len(stack) - 1; i >= 0; i-- {
+				if stack[i].name != name {
+					continue
+				}
+				end := t.span(t.span(start, 2).End, len([]rune(token.Name)))
+				if within(stack[i].Range) || within(end) {
+					return []Range{stack[i].Range, end}
+				}
+				stack = stack[:i]
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package html
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLinkedEditingRanges(t *testing.T) {
+	tests := []struct {
+		template string
+		// expected holds the offsets of the linked names.
+		expected []int
+	}{
+		{"<d|iv>text</div>", []int{1, 11}},
+		{"<div>text</div|>", []int{1, 11}},
+		{"<div><div>inner</di|v></div>", []int{6, 17}},
+		{"<di|v><div>inner</div></div>", []int{1, 23}},
+		{"<ul><li>one<li>two</u|l>", []int{1, 20}},
+		{"<b|r>", nil},
+		{"<d|iv>", nil},
+		{"<div>te|xt</div>", nil},
+	}
+
+	for _, test := range tests {
+		cursor := len([]rune(test.template[:strings.Index(test.template, "|")]))
+		template := strings.Replace(test.template, "|", "", 1)
+
+		var got []int
+		ranges := LinkedEditingRanges(template, cursor)
+		for _, r := range ranges {
+			got = append(got, r.Start.Cursor)
+		}
+		if len(ranges) == 2 && template[ranges[0].Start.Cursor:ranges[0].End.Cursor] != template[ranges[1].Start.Cursor:ranges[1].End.Cursor] {
+			t.Errorf("%q: linked ranges do not cover the same name", test.template)
+		}
+		if !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("%q: got %v, expected %v", test.template, got, test.expected)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package html
+
+import (
+	"iter"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+type Link struct {
+	// Tag is either `a` or `link`.
+	Tag  string
+	Href string
+	// URL is Href resolved against the base URL, nil if Href is not a valid URL.
+	URL       *url.URL
+	Rel       []string
+	Text      string
+	NoFollow  bool
+	Sponsored bool
+	UGC       bool
+	Location
+}
+
+// ExtractLinks returns all `<a href>` and `<link href>` elements with their URLs resolved against base, which
+// may be nil. The first `<base href>` in the document takes precedence over base, as in browsers, including for the
+// links before it.
+func ExtractLinks(tokens iter.Seq[Token], base *url.URL) ([]Link, error) {
+	var links []Link
+	anchor := -1
+	var text strings.Builder
+
+	all := slices.Collect(tokens)
+	base = BaseURL(slices.Values(all), base)
+	for _, token := range all {
+		switch token := token.(type) {
+		case *Illegal:
+			if token.Code != CodeMarkupDeclaration {
+				return links, token
+			}
+		case *Text:
+			if anchor >= 0 {
+				text.WriteString(token.Value)
+			}
+		case *EndTag:
+			if anchor >= 0 && strings.EqualFold(token.Name, "a") {
+				links[anchor].Text = strings.Join(strings.FieldsFunc(text.String(), isWhitespace), " ")
+				anchor = -1
+			}
+		case *StartTag:
+			name := strings.ToLower(token.Name)
+			href, ok := token.GetAttribute("href")
+
+			if (name != "a" && name != "link") || !ok {
+				continue
+			}
+
+			rel, _ := token.GetAttribute("rel")
+			link := Link{
+				Tag:      name,
+				Href:     href.Value,
+				Rel:      strings.FieldsFunc(strings.ToLower(rel.Value), isWhitespace),
+				Location: token.Location,
+			}
+			link.URL, _ = ResolveURL(base, href.Value)
+			link.NoFollow = slices.Contains(link.Rel, "nofollow")
+			link.Sponsored = slices.Contains(link.Rel, "sponsored")
+			link.UGC = slices.Contains(link.Rel, "ugc")
+
+			links = append(links, link)
+			if name == "a" && !token.IsSelfClosing {
+				anchor = len(links) - 1
+				text.Reset()
+			}
+		}
+	}
+
+	return links, nil
+}
@@ -0,0 +1,60 @@
+package html
+
+import (
+	"net/url"
+	"slices"
+	"testing"
+)
+
+func TestExtractLinks(t *testing.T) {
+	template := `<head>
+<link rel="stylesheet" href="/style.css">
+<base href="/docs/">
+</head>
+<body>
+<a href="intro.html">  Getting
+	<em>started</em> </a>
+<a HREF="https://ads.example.net/" Rel="nofollow SPONSORED">Ad</a>
+<a name="anchor-only">no href</a>
+<a href=" ../comments#1 " rel="ugc">comment</a>
+</body><!-- end -->`
+
+	base, _ := url.Parse("https://example.com/index.html")
+	links, err := ExtractLinks(Tokenize(template), base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(links) != 4 {
+		t.Fatalf("expected 4 links, got %d", len(links))
+	}
+
+	expected := []struct {
+		tag, url, text string
+		rel            []string
+	}{
+		{"link", "https://example.com/style.css", "", []string{"stylesheet"}},
+		{"a", "https://example.com/docs/intro.html", "Getting started", []string{}},
+		{"a", "https://ads.example.net/", "Ad", []string{"nofollow", "sponsored"}},
+		{"a", "https://example.com/comments#1", "comment", []string{"ugc"}},
+	}
+
+	for i, link := range links {
+		if link.Tag != expected[i].tag || link.URL.String() != expected[i].url || link.Text != expected[i].text || !slices.Equal(link.Rel, expected[i].rel) {
+			t.Errorf("link %d: expected %+v, got %+v", i, expected[i], link)
+		}
+	}
+
+	if !links[2].NoFollow || !links[2].Sponsored || links[2].UGC || !links[3].UGC {
+		t.Errorf("unexpected rel flags: %+v, %+v", links[2], links[3])
+	}
+}
+
+func TestExtractLinksBeforeBase(t *testing.T) {
+	links, err := ExtractLinks(Tokenize(`<link rel="icon" href="favicon.ico"><base href="/docs/">`), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(links) != 1 || links[0].URL.String() != "/docs/favicon.ico" {
+		t.Errorf("expected the base to apply to links before it, got %+v", links)
+	}
+}
@@ -0,0 +1,126 @@
+package html
+
+import (
+	"iter"
+	"slices"
+	"strings"
+)
+
+type Metadata struct {
+	Title       string
+	Description string
+	Canonical   string
+	// Refresh is the first valid `<meta http-equiv="refresh">`, nil if there is none.
+	Refresh   *Refresh
+	OpenGraph OpenGraph
+	Twitter   TwitterCard
+}
+
+// https://ogp.me/
+type OpenGraph struct {
+	Title       string
+	Description string
+	Type        string
+	URL         string
+	Image       string
+	SiteName    string
+	Locale      string
+}
+
+// https://developer.x.com/en/docs/x-for-websites/cards/overview/markup
+type TwitterCard struct {
+	Card        string
+	Site        string
+	Creator     string
+	Title       string
+	Description string
+	Image       string
+}
+
+// ExtractMetadata collects the page title, meta description, canonical URL, meta refresh, Open Graph and Twitter
+// card fields.
+// When a field is declared more than once, the first declaration wins.
+func ExtractMetadata(tokens iter.Seq[Token]) (Metadata, error) {
+	var metadata Metadata
+	inTitle, seenTitle := false, false
+
+	set := func(field *string, value string) {
+		if *field == "" {
+			*field = strings.TrimSpace(value)
+		}
+	}
+
+	for token := range tokens {
+		switch token := token.(type) {
+		case *Illegal:
+			if token.Code != CodeMarkupDeclaration {
+				return metadata, token
+			}
+		case *Text:
+			if inTitle {
+				metadata.Title += token.Value
+			}
+		case *EndTag:
+			if strings.EqualFold(token.Name, "title") && inTitle {
+				inTitle, seenTitle = false, true
+				metadata.Title = strings.TrimSpace(metadata.Title)
+			}
+		case *StartTag:
+			switch strings.ToLower(token.Name) {
+			case "title":
+				inTitle = !seenTitle && !token.IsSelfClosing
+			case "link":
+				rel, _ := token.GetAttribute("rel")
+				if slices.Contains(strings.Fields(strings.ToLower(rel.Value)), "canonical") {
+					href, _ := token.GetAttribute("href")
+					set(&metadata.Canonical, href.Value)
+				}
+			case "meta":
+				content, _ := token.GetAttribute("content")
+				if equiv, _ := token.GetAttribute("http-equiv"); strings.EqualFold(equiv.Value, "refresh") && metadata.Refresh == nil {
+					if refresh, ok := ParseMetaRefresh(content.Value); ok {
+						metadata.Refresh = &refresh
+					}
+				}
+
+				name, _ := token.GetAttribute("name")
+				if property, ok := token.GetAttribute("property"); ok && name.Value == "" {
+					name = property
+				}
+
+				switch strings.ToLower(name.Value) {
+				case "description":
+					set(&metadata.Description, content.Value)
+				case "og:title":
+					set(&metadata.OpenGraph.Title, content.Value)
+				case "og:description":
+					set(&metadata.OpenGraph.Description, content.Value)
+				case "og:type":
+					set(&metadata.OpenGraph.Type, content.Value)
+				case "og:url":
+					set(&metadata.OpenGraph.URL, content.Value)
+				case "og:image", "og:image:url":
+					set(&metadata.OpenGraph.Image, content.Value)
+				case "og:site_name":
+					set(&metadata.OpenGraph.SiteName, content.Value)
+				case "og:locale":
+					set(&metadata.OpenGraph.Locale, content.Value)
+				case "twitter:card":
+					set(&metadata.Twitter.Card, content.Value)
+				case "twitter:site":
+					set(&metadata.Twitter.Site, content.Value)
+				case "twitter:creator":
+					set(&metadata.Twitter.Creator, content.Value)
+				case "twitter:title":
+					set(&metadata.Twitter.Title, content.Value)
+				case "twitter:description":
+					set(&metadata.Twitter.Description, content.Value)
+				case "twitter:image":
+					set(&metadata.Twitter.Image, content.Value)
+				}
+			}
+		}
+	}
+
+	return metadata, nil
+}
@@ -0,0 +1,45 @@
+package html
+
+import "testing"
+
+func TestExtractMetadata(t *testing.T) {
+	template := `<!DOCTYPE html>
+<html>
+<head>
+	<title> Example   page </title>
+	<meta name="description" content="An example page">
+	<meta name="description" content="ignored duplicate">
+	<link REL="canonical" Href="https://example.com/page">
+	<meta PROPERTY="og:title" Content="Example">
+	<meta property="og:image" content="https://example.com/cover.png">
+	<meta property="og:site_name" content="Example Site">
+	<meta name="twitter:card" content="summary_large_image">
+	<meta name="twitter:creator" content="@example">
+</head>
+<body><title>not the title</title></body>
+</html><!-- end -->`
+
+	metadata, err := ExtractMetadata(Tokenize(template))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := Metadata{
+		Title:       "Example   page",
+		Description: "An example page",
+		Canonical:   "https://example.com/page",
+		OpenGraph: OpenGraph{
+			Title:    "Example",
+			Image:    "https://example.com/cover.png",
+			SiteName: "Example Site",
+		},
+		Twitter: TwitterCard{
+			Card:    "summary_large_image",
+			Creator: "@example",
+		},
+	}
+
+	if metadata != expected {
+		t.Errorf("expected %+v, got %+v", expected, metadata)
+	}
+}
@@ -0,0 +1,69 @@
+package html
+
+import (
+	"maps"
+	"time"
+	"unicode/utf8"
+)
+
+// Metrics describes the work done by a tokenizer, e.g. to be exported to a monitoring system.
+type Metrics struct {
+	// Tokens counts the tokens read by kind, e.g. Tokens["START_TAG"], excluding EOF.
+	Tokens map[string]int
+	// Errors counts the illegal tokens, which are also counted in Tokens.
+	Errors int
+	// Bytes is the length of the input read, encoded as UTF-8.
+	Bytes int
+	// Duration is the time spent tokenizing, excluding the time consumers spend between tokens.
+	Duration time.Duration
+}
+
+type tokenizerMetrics struct {
+	Metrics
+	report   func(Metrics)
+	reported bool
+}
+
+// EnableMetrics makes the tokenizer count the tokens it reads and time itself, reporting the metrics once it reaches
+// the end of input if report is not nil. Metrics of a tokenizer whose tokens are not all read are returned by the
+// Metrics method.
+func (t *Tokenizer) EnableMetrics(report func(Metrics)) {
+	t.metrics = &tokenizerMetrics{Metrics: Metrics{Tokens: map[string]int{}}, report: report}
+}
+
+// Metrics returns the metrics collected so far, zero unless metrics are enabled. With Parallel, tokens are counted
+// once, as they are returned, and Duration includes the time chunks are tokenized concurrently.
+func (t *Tokenizer) Metrics() Metrics {
+	if t.metrics == nil {
+		return Metrics{}
+	}
+	metrics := t.metrics.Metrics
+	metrics.Tokens = maps.Clone(metrics.Tokens)
+	return metrics
+}
+
+// record accounts for a token read from the start cursor since the given time.
+func (t *Tokenizer) record(token *RawToken, start int, since time.Time) {
+	t.metrics.Duration += time.Since(since)
+	t.count(token.Kind, start)
+}
+
+// count accounts for a token of the kind read from the start cursor, reporting the metrics at the end of input.
+func (t *Tokenizer) count(kind string, start int) {
+	m := t.metrics
+	for _, c := range t.template[start:t.i] {
+		m.Bytes += utf8.RuneLen(c)
+	}
+
+	switch kind {
+	case "EOF":
+		if m.report != nil && !m.reported {
+			m.reported = true
+			m.report(t.Metrics())
+		}
+		return
+	case "ILLEGAL":
+		m.Errors++
+	}
+	m.Tokens[kind]++
+}
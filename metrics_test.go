@@ -0,0 +1,55 @@
+package html
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestMetrics(t *testing.T) {
+	var reported []Metrics
+	tokenizer := NewTokenizer("<p>héllo</p><div id=x>")
+	tokenizer.EnableMetrics(func(metrics Metrics) { reported = append(reported, metrics) })
+	for range tokenizer.All() {
+	}
+	tokenizer.Diagnostics()
+
+	if len(reported) != 1 {
+		t.Fatalf("expected the metrics to be reported once, got %v", reported)
+	}
+	metrics := reported[0]
+	expected := map[string]int{"START_TAG": 1, "TEXT": 2, "END_TAG": 1, "ILLEGAL": 1}
+	if !maps.Equal(metrics.Tokens, expected) || metrics.Errors != 1 || metrics.Bytes != len("<p>héllo</p><div id=x>") {
+		t.Errorf("unexpected metrics %+v", metrics)
+	}
+	if metrics.Duration <= 0 {
+		t.Errorf("expected a duration, got %v", metrics.Duration)
+	}
+}
+
+func TestStreamMetrics(t *testing.T) {
+	tokenizer := NewTokenizer("")
+	tokenizer.EnableMetrics(nil)
+	stream := tokenizer.Stream(func(Token) error { return nil })
+	for _, chunk := range []string{"<di", "v cla", "ss=\"a\">te", "xt</div>"} {
+		stream.Write([]byte(chunk))
+	}
+
+	metrics := tokenizer.Metrics()
+	if expected := map[string]int{"START_TAG": 1, "TEXT": 1, "END_TAG": 1}; !maps.Equal(metrics.Tokens, expected) {
+		t.Errorf("got %v, expected %v", metrics.Tokens, expected)
+	}
+}
+
+func TestStreamFlushMetrics(t *testing.T) {
+	tokenizer := NewTokenizer("")
+	tokenizer.EnableMetrics(nil)
+	stream := tokenizer.Stream(func(Token) error { return nil })
+	stream.Write([]byte("<p>some text"))
+	stream.Flush()
+	stream.Close()
+
+	metrics := tokenizer.Metrics()
+	if expected := map[string]int{"START_TAG": 1, "TEXT": 1}; !maps.Equal(metrics.Tokens, expected) {
+		t.Errorf("got %v, expected %v", metrics.Tokens, expected)
+	}
+}
@@ -0,0 +1,125 @@
+package html
+
+import "strings"
+
+// Namespaces of the attributes adjusted in foreign content.
+const (
+	XLinkNamespace = "http://www.w3.org/1999/xlink"
+	XMLNamespace   = "http://www.w3.org/XML/1998/namespace"
+	XMLNSNamespace = "http://www.w3.org/2000/xmlns/"
+)
+
+// Namespaces of foreign elements, whose attribute names ForeignName adjusts.
+const (
+	SVGNamespace    = "http://www.w3.org/2000/svg"
+	MathMLNamespace = "http://www.w3.org/1998/Math/MathML"
+)
+
+// svgAttributes maps the lowercase names of SVG attributes written in camel case to their adjusted names.
+// https://html.spec.whatwg.org/multipage/parsing.html#adjust-svg-attributes
+var svgAttributes = map[string]string{
+	"attributename":       "attributeName",
+	"attributetype":       "attributeType",
+	"basefrequency":       "baseFrequency",
+	"baseprofile":         "baseProfile",
+	"calcmode":            "calcMode",
+	"clippathunits":       "clipPathUnits",
+	"diffuseconstant":     "diffuseConstant",
+	"edgemode":            "edgeMode",
+	"filterunits":         "filterUnits",
+	"glyphref":            "glyphRef",
+	"gradienttransform":   "gradientTransform",
+	"gradientunits":       "gradientUnits",
+	"kernelmatrix":        "kernelMatrix",
+	"kernelunitlength":    "kernelUnitLength",
+	"keypoints":           "keyPoints",
+	"keysplines":          "keySplines",
+	"keytimes":            "keyTimes",
+	"lengthadjust":        "lengthAdjust",
+	"limitingconeangle":   "limitingConeAngle",
+	"markerheight":        "markerHeight",
+	"markerunits":         "markerUnits",
+	"markerwidth":         "markerWidth",
+	"maskcontentunits":    "maskContentUnits",
+	"maskunits":           "maskUnits",
+	"numoctaves":          "numOctaves",
+	"pathlength":          "pathLength",
+	"patterncontentunits": "patternContentUnits",
+	"patterntransform":    "patternTransform",
+	"patternunits":        "patternUnits",
+	"pointsatx":           "pointsAtX",
+	"pointsaty":           "pointsAtY",
+	"pointsatz":           "pointsAtZ",
+	"preservealpha":       "preserveAlpha",
+	"preserveaspectratio": "preserveAspectRatio",
+	"primitiveunits":      "primitiveUnits",
+	"refx":                "refX",
+	"refy":                "refY",
+	"repeatcount":         "repeatCount",
+	"repeatdur":           "repeatDur",
+	"requiredextensions":  "requiredExtensions",
+	"requiredfeatures":    "requiredFeatures",
+	"specularconstant":    "specularConstant",
+	"specularexponent":    "specularExponent",
+	"spreadmethod":        "spreadMethod",
+	"startoffset":         "startOffset",
+	"stddeviation":        "stdDeviation",
+	"stitchtiles":         "stitchTiles",
+	"surfacescale":        "surfaceScale",
+	"systemlanguage":      "systemLanguage",
+	"tablevalues":         "tableValues",
+	"targetx":             "targetX",
+	"targety":             "targetY",
+	"textlength":          "textLength",
+	"viewbox":             "viewBox",
+	"viewtarget":          "viewTarget",
+	"xchannelselector":    "xChannelSelector",
+	"ychannelselector":    "yChannelSelector",
+	"zoomandpan":          "zoomAndPan",
+}
+
+// foreignAttributes maps the attributes with a namespace in foreign content to their prefix, local name and
+// namespace.
+// https://html.spec.whatwg.org/multipage/parsing.html#adjust-foreign-attributes
+var foreignAttributes = map[string][3]string{
+	"xlink:actuate": {"xlink", "actuate", XLinkNamespace},
+	"xlink:arcrole": {"xlink", "arcrole", XLinkNamespace},
+	"xlink:href":    {"xlink", "href", XLinkNamespace},
+	"xlink:role":    {"xlink", "role", XLinkNamespace},
+	"xlink:show":    {"xlink", "show", XLinkNamespace},
+	"xlink:title":   {"xlink", "title", XLinkNamespace},
+	"xlink:type":    {"xlink", "type", XLinkNamespace},
+	"xml:lang":      {"xml", "lang", XMLNamespace},
+	"xml:space":     {"xml", "space", XMLNamespace},
+	"xmlns":         {"", "xmlns", XMLNSNamespace},
+	"xmlns:xlink":   {"xmlns", "xlink", XMLNSNamespace},
+}
+
+// SplitName splits the name at its first colon into a prefix and a local name, the prefix being empty for names
+// without a colon. Names are split as written, HTML attributes having no namespaces; see ForeignName for attributes
+// of SVG and MathML elements.
+func (a Attribute) SplitName() (prefix, local string) {
+	prefix, local, ok := strings.Cut(a.Name, ":")
+	if !ok {
+		return "", a.Name
+	}
+	return prefix, local
+}
+
+// ForeignName returns the prefix, local name and namespace the attribute has on an element in the given namespace,
+// SVGNamespace or MathMLNamespace. Only the xlink, xml and xmlns attributes listed by the HTML parser get a prefix and
+// a namespace, any other attribute, colon included, being a lowercase local name without a namespace, except for the
+// SVG attributes written in camel case, such as viewBox, and MathML's definitionURL.
+func (a Attribute) ForeignName(element string) (prefix, local, namespace string) {
+	name := strings.ToLower(a.Name)
+	if adjusted, ok := foreignAttributes[name]; ok {
+		return adjusted[0], adjusted[1], adjusted[2]
+	}
+	switch {
+	case element == SVGNamespace && svgAttributes[name] != "":
+		return "", svgAttributes[name], ""
+	case element == MathMLNamespace && name == "definitionurl":
+		return "", "definitionURL", ""
+	}
+	return "", name, ""
+}
@@ -0,0 +1,40 @@
+package html
+
+import "testing"
+
+func TestAttributeNames(t *testing.T) {
+	tag := firstStartTag(`<use XLink:Href="#icon" xml:lang="en" xmlns="http://www.w3.org/2000/svg" xmlns:xlink="x" data-a:b="c" class="icon" VIEWBOX="0 0 1 1" definitionurl="x">`)
+
+	tests := map[string][5]string{
+		"XLink:Href":    {"XLink", "Href", "xlink", "href", XLinkNamespace},
+		"xml:lang":      {"xml", "lang", "xml", "lang", XMLNamespace},
+		"xmlns":         {"", "xmlns", "", "xmlns", XMLNSNamespace},
+		"xmlns:xlink":   {"xmlns", "xlink", "xmlns", "xlink", XMLNSNamespace},
+		"data-a:b":      {"data-a", "b", "", "data-a:b", ""},
+		"class":         {"", "class", "", "class", ""},
+		"VIEWBOX":       {"", "VIEWBOX", "", "viewBox", ""},
+		"definitionurl": {"", "definitionurl", "", "definitionurl", ""},
+	}
+
+	for name, expected := range tests {
+		attribute, ok := tag.Attributes[name]
+		if !ok {
+			t.Fatalf("missing attribute %s", name)
+		}
+		prefix, local := attribute.SplitName()
+		foreignPrefix, foreignLocal, namespace := attribute.ForeignName(SVGNamespace)
+		if got := [5]string{prefix, local, foreignPrefix, foreignLocal, namespace}; got != expected {
+			t.Errorf("%s: got %q, expected %q", name, got, expected)
+		}
+	}
+}
+
+func TestMathMLAttributeNames(t *testing.T) {
+	tag := firstStartTag(`<math DefinitionURL="x" viewbox="0 0 1 1">`)
+	if _, local, _ := tag.Attributes["DefinitionURL"].ForeignName(MathMLNamespace); local != "definitionURL" {
+		t.Errorf("expected definitionURL, got %q", local)
+	}
+	if _, local, _ := tag.Attributes["viewbox"].ForeignName(MathMLNamespace); local != "viewbox" {
+		t.Errorf("expected SVG attributes not to be adjusted on MathML elements, got %q", local)
+	}
+}
@@ -0,0 +1,125 @@
+package html
+
+import (
+	"iter"
+	"slices"
+	"strings"
+)
+
+var sectioningElements = []string{"article", "aside", "nav", "section"}
+
+type Heading struct {
+	// Level is the rank of the heading, 1 for `<h1>` through 6 for `<h6>`.
+	Level    int
+	Text     string
+	ID       string
+	Children []*Heading
+	Location
+}
+
+// Outline builds the heading hierarchy of a document. Headings nest under the closest preceding heading of a lower
+// rank, while headings inside a sectioning element (article, aside, nav, section) always nest under the heading
+// preceding that element.
+func Outline(tokens iter.Seq[Token]) ([]*Heading, error) {
+	type entry struct {
+		heading *Heading
+		depth   int
+	}
+
+	var (
+		roots   []*Heading
+		stack   []entry
+		current *Heading
+		text    strings.Builder
+		depth   int
+	)
+
+	for token := range tokens {
+		switch token := token.(type) {
+		case *Illegal:
+			if token.Code != CodeMarkupDeclaration {
+				return roots, token
+			}
+		case *Text:
+			if current != nil {
+				text.WriteString(token.Value)
+			}
+		case *StartTag:
+			name := strings.ToLower(token.Name)
+			if slices.Contains(sectioningElements, name) && !token.IsSelfClosing {
+				depth++
+				continue
+			}
+
+			level := headingLevel(name)
+			if level == 0 || current != nil || token.IsSelfClosing {
+				continue
+			}
+
+			current = &Heading{Level: level, ID: token.ID(), Location: token.Location}
+			text.Reset()
+
+			for len(stack) > 0 && stack[len(stack)-1].depth == depth && stack[len(stack)-1].heading.Level >= level {
+				stack = stack[:len(stack)-1]
+			}
+			if len(stack) == 0 {
+				roots = append(roots, current)
+			} else {
+				parent := stack[len(stack)-1].heading
+				parent.Children = append(parent.Children, current)
+			}
+			stack = append(stack, entry{current, depth})
+		case *EndTag:
+			name := strings.ToLower(token.Name)
+			if slices.Contains(sectioningElements, name) && depth > 0 {
+				depth--
+				for len(stack) > 0 && stack[len(stack)-1].depth > depth {
+					stack = stack[:len(stack)-1]
+				}
+			} else if current != nil && headingLevel(name) != 0 {
+				current.Text = strings.Join(strings.FieldsFunc(text.String(), isWhitespace), " ")
+				current = nil
+			}
+		}
+	}
+
+	return roots, nil
+}
+
+// RenderTOC renders the outline as nested `<ul>` lists. Headings with an id link to their fragment.
+// Heading texts are emitted as they appeared in the source.
+func RenderTOC(headings []*Heading) string {
+	var b strings.Builder
+	renderTOC(&b, headings)
+	return b.String()
+}
+
+func renderTOC(b *strings.Builder, headings []*Heading) {
+	if len(headings) == 0 {
+		return
+	}
+
+	b.WriteString("<ul>")
+	for _, heading := range headings {
+		b.WriteString("<li>")
+		if heading.ID != "" {
+			b.WriteString(`<a href="#`)
+			b.WriteString(strings.ReplaceAll(heading.ID, `"`, "&quot;"))
+			b.WriteString(`">`)
+			b.WriteString(heading.Text)
+			b.WriteString("</a>")
+		} else {
+			b.WriteString(heading.Text)
+		}
+		renderTOC(b, heading.Children)
+		b.WriteString("</li>")
+	}
+	b.WriteString("</ul>")
+}
+
+func headingLevel(name string) int {
+	if len(name) == 2 && name[0] == 'h' && name[1] >= '1' && name[1] <= '6' {
+		return int(name[1] - '0')
+	}
+	return 0
+}
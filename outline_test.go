@@ -0,0 +1,39 @@
+package html
+
+import "testing"
+
+func TestOutline(t *testing.T) {
+	template := `<h1 id="guide">Guide</h1>
+<h2 ID="install">Install</h2>
+<h3>From <code>source</code></h3>
+<section><h1 id="usage">Usage</h1><h2>Flags</h2></section>
+<h2 id="faq">FAQ</h2><!-- end -->`
+
+	headings, err := Outline(Tokenize(template))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(headings) != 1 || len(headings[0].Children) != 2 {
+		t.Fatalf("unexpected outline shape: %+v", headings)
+	}
+
+	install, faq := headings[0].Children[0], headings[0].Children[1]
+	if install.Text != "Install" || len(install.Children) != 1 || install.Children[0].Text != "From source" {
+		t.Fatalf("unexpected install heading: %+v", install)
+	}
+	if source := install.Children[0]; len(source.Children) != 1 || source.Children[0].Text != "Usage" {
+		t.Fatalf("sectioned heading must nest under the preceding heading: %+v", source)
+	}
+	if usage := install.Children[0].Children[0]; usage.Level != 1 || len(usage.Children) != 1 || usage.Children[0].Text != "Flags" {
+		t.Errorf("unexpected usage heading: %+v", usage)
+	}
+	if faq.ID != "faq" || faq.Line != 5 {
+		t.Errorf("unexpected faq heading: %+v", faq)
+	}
+
+	expected := `<ul><li><a href="#guide">Guide</a><ul><li><a href="#install">Install</a><ul><li>From source<ul><li><a href="#usage">Usage</a><ul><li>Flags</li></ul></li></ul></li></ul></li><li><a href="#faq">FAQ</a></li></ul></li></ul>`
+	if toc := RenderTOC(headings); toc != expected {
+		t.Errorf("expected %s, got %s", expected, toc)
+	}
+}
@@ -0,0 +1,142 @@
+package html
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// Parallel tokenizes the remaining input in up to the given number of chunks concurrently, returning the same tokens
+// as All would. Chunks start at a `<` near evenly spaced offsets. Since a chunk boundary may fall inside a token or
+// raw text, each chunk's tokens are used only from the first token at which the previous chunk's tokenization
+// resynchronizes with it, anything before being tokenized again sequentially. Traces and metrics only cover the
+// tokens returned.
+func (t *Tokenizer) Parallel(chunks int) []Token {
+	boundaries := t.chunkBoundaries(chunks)
+	results := make([][]chunkEntry, len(boundaries))
+	started := time.Now()
+
+	var wg sync.WaitGroup
+	for i, boundary := range boundaries {
+		end := len(t.template)
+		if i+1 < len(boundaries) {
+			end = boundaries[i+1].Cursor
+		}
+
+		// Chunks leave tracing and metrics to the sequential pass, which only accounts for the tokens it keeps.
+		chunk := *t
+		chunk.i, chunk.line, chunk.column, chunk.rawText = boundary.Cursor, boundary.Line, boundary.Column, nil
+		chunk.trace, chunk.metrics = nil, nil
+		if i == 0 {
+			chunk.rawText = t.rawText
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = chunk.chunk(end)
+		}()
+	}
+	wg.Wait()
+	if t.metrics != nil {
+		t.metrics.Duration += time.Since(started)
+	}
+
+	var tokens []Token
+	sequential := *t
+	for _, entries := range results {
+		for len(entries) > 0 {
+			if j := slices.IndexFunc(entries, sequential.syncedWith); j >= 0 {
+				for _, entry := range entries[j:] {
+					tokens = append(tokens, entry.token)
+					sequential.keep(entry)
+				}
+				sequential = entries[len(entries)-1].after.withHooksOf(t)
+				break
+			}
+
+			if sequential.i > entries[len(entries)-1].start {
+				break
+			}
+			token := sequential.next()
+			if token.Kind() == "EOF" {
+				break
+			}
+			tokens = append(tokens, token)
+		}
+	}
+
+	for token := sequential.next(); token.Kind() != "EOF"; token = sequential.next() {
+		tokens = append(tokens, token)
+	}
+
+	*t = sequential
+	return tokens
+}
+
+// keep traces and accounts for a token of a chunk taken as is.
+func (t *Tokenizer) keep(entry chunkEntry) {
+	after := entry.after.withHooksOf(t)
+	if t.trace != nil {
+		after.traceToken(entry.token, entry.before.location(), entry.before.rawText)
+	}
+	if t.metrics != nil {
+		after.count(entry.token.Kind(), entry.start)
+	}
+}
+
+// withHooksOf returns the tokenizer state with the trace and metrics of another tokenizer, which chunks do without.
+func (t Tokenizer) withHooksOf(other *Tokenizer) Tokenizer {
+	t.trace, t.metrics = other.trace, other.metrics
+	return t
+}
+
+type chunkEntry struct {
+	token Token
+	start int
+	// before and after hold the tokenizer state around the token.
+	before Tokenizer
+	after  Tokenizer
+}
+
+// chunk tokenizes until a token starts at or after end.
+func (t *Tokenizer) chunk(end int) []chunkEntry {
+	var entries []chunkEntry
+	for t.i < end {
+		before := *t
+		token := t.next()
+		if token.Kind() == "EOF" {
+			break
+		}
+		entries = append(entries, chunkEntry{token, before.i, before, *t})
+	}
+	return entries
+}
+
+// syncedWith reports whether tokenizing from the current state yields the entry's token and everything after it.
+func (t *Tokenizer) syncedWith(entry chunkEntry) bool {
+	return t.i == entry.start && slices.Equal(t.rawText, entry.before.rawText)
+}
+
+func (t *Tokenizer) chunkBoundaries(chunks int) []Location {
+	boundaries := []Location{t.location()}
+	if chunks < 2 {
+		return boundaries
+	}
+
+	size := (len(t.template) - t.i) / chunks
+	location := t.location()
+	for k := 1; k < chunks; k++ {
+		target := t.i + k*size
+		for location.Cursor < len(t.template) && (location.Cursor < target || t.template[location.Cursor] != '<') {
+			location = advanceLocation(location, t.template[location.Cursor])
+		}
+		if location.Cursor >= len(t.template) {
+			break
+		}
+		if location.Cursor > boundaries[len(boundaries)-1].Cursor {
+			boundaries = append(boundaries, location)
+		}
+	}
+	return boundaries
+}
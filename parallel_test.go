@@ -0,0 +1,49 @@
+package html
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParallel(t *testing.T) {
+	template := strings.Repeat(`<!DOCTYPE html>
+<div class="item" title="a < b">Hello <b>world</b> &amp; 1 < 2</div>
+<script>if (a <b) { document.write("<p>x</p>") }</script>
+<p id='x'>text <br/> more</p><!-- comment <div> -->
+`, 50)
+
+	expected := NewTokenizer(template)
+	var sequential []Token
+	for token := range expected.All() {
+		sequential = append(sequential, token)
+	}
+
+	for _, chunks := range []int{1, 2, 3, 7, 16, 64, 1000} {
+		tokenizer := NewTokenizer(template)
+		if tokens := tokenizer.Parallel(chunks); !reflect.DeepEqual(tokens, sequential) {
+			t.Errorf("%d chunks: parallel tokenization differs from sequential, got %d tokens, expected %d", chunks, len(tokens), len(sequential))
+		}
+	}
+}
+
+func TestParallelMetrics(t *testing.T) {
+	template := strings.Repeat("<div class=\"a\">text <b>bold</b></div>\n<script>a < b</script>", 200)
+
+	expected := NewTokenizer(template)
+	expected.EnableMetrics(nil)
+	for range expected.All() {
+	}
+
+	var reported []Metrics
+	tokenizer := NewTokenizer(template)
+	tokenizer.EnableMetrics(func(metrics Metrics) { reported = append(reported, metrics) })
+	tokenizer.Parallel(8)
+
+	if len(reported) != 1 {
+		t.Fatalf("expected the metrics to be reported once, got %d reports", len(reported))
+	}
+	if got, want := reported[0], expected.Metrics(); !reflect.DeepEqual(got.Tokens, want.Tokens) || got.Bytes != want.Bytes {
+		t.Errorf("got %v and %d bytes, expected %v and %d bytes", got.Tokens, got.Bytes, want.Tokens, want.Bytes)
+	}
+}
@@ -0,0 +1,147 @@
+package html
+
+import (
+	"cmp"
+	"iter"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Stage transforms a token stream, e.g. dropping or rewriting tokens.
+type Stage func(iter.Seq[Token]) iter.Seq[Token]
+
+// Pipe passes the tokens through the stages in order.
+func Pipe(tokens iter.Seq[Token], stages ...Stage) iter.Seq[Token] {
+	for _, stage := range stages {
+		tokens = stage(tokens)
+	}
+	return tokens
+}
+
+// Filter returns a stage keeping only the tokens for which keep returns true.
+func Filter(keep func(Token) bool) Stage {
+	return func(tokens iter.Seq[Token]) iter.Seq[Token] {
+		return func(yield func(Token) bool) {
+			for token := range tokens {
+				if keep(token) && !yield(token) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Map returns a stage replacing every token with the result of f.
+func Map(f func(Token) Token) Stage {
+	return func(tokens iter.Seq[Token]) iter.Seq[Token] {
+		return func(yield func(Token) bool) {
+			for token := range tokens {
+				if !yield(f(token)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// DropWhitespaceText drops text tokens consisting only of whitespace.
+var DropWhitespaceText = Filter(func(token Token) bool {
+	text, ok := token.(*Text)
+	return !ok || strings.TrimFunc(text.Value, isWhitespace) != ""
+})
+
+// DropComments drops the illegal tokens reported for comments and other markup declarations.
+var DropComments = Filter(func(token Token) bool {
+	illegal, ok := token.(*Illegal)
+	return !ok || illegal.Code != CodeMarkupDeclaration
+})
+
+// LowercaseNames lowercases the names of tags and attributes, copying the tokens it changes. Of attributes whose names
+// differ only in case, the first in the source is kept.
+var LowercaseNames = Map(func(token Token) Token {
+	switch token := token.(type) {
+	case *StartTag:
+		lowercase := *token
+		lowercase.Name = strings.ToLower(token.Name)
+		if token.Attributes != nil {
+			lowercase.Attributes = make(map[string]Attribute, len(token.Attributes))
+			names := slices.SortedFunc(maps.Keys(token.Attributes), func(a, b string) int {
+				return cmp.Or(token.Attributes[a].NameLocation.Cursor-token.Attributes[b].NameLocation.Cursor, strings.Compare(a, b))
+			})
+			for _, name := range names {
+				attribute := token.Attributes[name]
+				if attribute.Name = strings.ToLower(name); !lowercase.HasAttribute(attribute.Name) {
+					lowercase.Attributes[attribute.Name] = attribute
+				}
+			}
+		}
+		return &lowercase
+	case *EndTag:
+		lowercase := *token
+		lowercase.Name = strings.ToLower(token.Name)
+		return &lowercase
+	}
+	return token
+})
+
+// CollapseWhitespace replaces every run of whitespace in text with a single space, copying the tokens it changes.
+// Runs are never removed entirely, as whitespace between inline elements is rendered, and text within pre, listing
+// and the raw text elements, such as textarea, is kept as is.
+var CollapseWhitespace Stage = func(tokens iter.Seq[Token]) iter.Seq[Token] {
+	return func(yield func(Token) bool) {
+		var preserving []string
+		for token := range tokens {
+			switch token := token.(type) {
+			case *StartTag:
+				name := strings.ToLower(token.Name)
+				if !token.IsSelfClosing && (name == "pre" || name == "listing" || isRawTextElement([]rune(name))) {
+					preserving = append(preserving, name)
+				}
+			case *EndTag:
+				name := strings.ToLower(token.Name)
+				for i := len(preserving) - 1; i >= 0; i-- {
+					if preserving[i] == name {
+						preserving = preserving[:i]
+						break
+					}
+				}
+			case *Text:
+				if len(preserving) == 0 {
+					if collapsed := collapseWhitespace(token.Value); collapsed != token.Value {
+						text := *token
+						text.Value = collapsed
+						if !yield(&text) {
+							return
+						}
+						continue
+					}
+				}
+			}
+
+			if !yield(token) {
+				return
+			}
+		}
+	}
+}
+
+func collapseWhitespace(s string) string {
+	var b strings.Builder
+	space := false
+	for _, c := range s {
+		if isWhitespace(c) {
+			space = true
+			continue
+		}
+		if space {
+			b.WriteByte(' ')
+			space = false
+		}
+		b.WriteRune(c)
+	}
+	if space {
+		b.WriteByte(' ')
+	}
+	return b.String()
+}
@@ -0,0 +1,106 @@
+package html
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPipe(t *testing.T) {
+	template := "<DIV Class=\"a\">\n  <!-- note -->\n  <P>text</P>\n</DIV>"
+
+	dropParagraphs := Filter(func(token Token) bool {
+		switch token := token.(type) {
+		case *StartTag:
+			return token.Name != "p"
+		case *EndTag:
+			return token.Name != "p"
+		}
+		return true
+	})
+
+	var got []string
+	for token := range Pipe(Tokenize(template), DropComments, DropWhitespaceText, LowercaseNames, dropParagraphs) {
+		got = append(got, strings.Fields(token.(fmt.Stringer).String())[0])
+	}
+
+	expected := []string{`<div`, `"text"`, `</div>`}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %q, expected %q", got, expected)
+	}
+}
+
+func TestLowercaseNamesCopies(t *testing.T) {
+	var original *StartTag
+	for token := range Tokenize(`<A HREF="/x">`) {
+		original = token.(*StartTag)
+	}
+
+	lowercase := LowercaseNames(func(yield func(Token) bool) { yield(original) })
+	for token := range lowercase {
+		tag := token.(*StartTag)
+		if tag.Name != "a" || tag.Attributes["href"].Name != "href" {
+			t.Errorf("unexpected lowercased tag %v", tag)
+		}
+	}
+	if original.Name != "A" || original.Attributes["HREF"].Name != "HREF" {
+		t.Errorf("original tag was modified: %v", original)
+	}
+}
+
+func TestLowercaseNamesKeepsFirstAttribute(t *testing.T) {
+	tag := &StartTag{Name: "P", Attributes: map[string]Attribute{
+		"id": {Name: "id", Value: "second", NameLocation: Location{Line: 1, Column: 10, Cursor: 9}},
+		"ID": {Name: "ID", Value: "first", NameLocation: Location{Line: 1, Column: 4, Cursor: 3}},
+	}}
+
+	for range 10 {
+		for token := range LowercaseNames(func(yield func(Token) bool) { yield(tag) }) {
+			attributes := token.(*StartTag).Attributes
+			if len(attributes) != 1 || attributes["id"].Value != "first" {
+				t.Fatalf("got %v, expected only the first id", attributes)
+			}
+		}
+	}
+}
+
+func TestPipeStopsEarly(t *testing.T) {
+	count := 0
+	for range Pipe(Tokenize("<a></a><b></b>"), LowercaseNames, DropWhitespaceText) {
+		count++
+		break
+	}
+	if count != 1 {
+		t.Errorf("expected to stop after one token, got %d", count)
+	}
+}
+
+func TestCollapseWhitespace(t *testing.T) {
+	template := "<p>\n  a  \t b\n</p><pre>  keep\n  this </pre><textarea> and  this</textarea><script>if (a)  {}</script><span>  </span>"
+
+	var got []string
+	for token := range Pipe(Tokenize(template), CollapseWhitespace) {
+		if text, ok := token.(*Text); ok {
+			got = append(got, text.Value)
+		}
+	}
+
+	expected := []string{" a b ", "  keep\n  this ", " and  this", "if (a)  {}", " "}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %q, expected %q", got, expected)
+	}
+}
+
+func TestCollapseWhitespaceNested(t *testing.T) {
+	var got []string
+	for token := range Pipe(Tokenize("<pre><pre></pre>  x  </pre>  y  "), CollapseWhitespace) {
+		if text, ok := token.(*Text); ok {
+			got = append(got, text.Value)
+		}
+	}
+
+	if expected := []string{"  x  ", " y "}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected whitespace to be kept until the outer element closes, got %q, expected %q", got, expected)
+	}
+}
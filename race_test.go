@@ -0,0 +1,6 @@
+//go:build race
+
+package html
+
+// raceEnabled tells whether the tests run with the race detector, which makes allocation counts meaningless.
+const raceEnabled = true
@@ -0,0 +1,125 @@
+package html
+
+import (
+	"slices"
+
+	"github.com/terawatthour/html/atom"
+)
+
+// RawToken is a reusable token filled in by Tokenizer.NextInto. Its rune slices alias the tokenizer's input and
+// its buffers are reused by the next call, so nothing must be retained from it across calls.
+type RawToken struct {
+	// Kind is the Kind of the corresponding Token type, e.g. START_TAG.
+	Kind string
+	// Name of start and end tags.
+	Name []rune
+	Atom atom.Atom
+	// Data holds the value of text and expression tokens.
+	Data []rune
+	// Code and Reason of illegal tokens.
+	Code          string
+	Reason        string
+	HasSystem     bool
+	IsSelfClosing bool
+	// Attributes of start tags in source order, empty when the tokenizer has lazy attributes enabled.
+	Attributes []RawAttribute
+	// Expressions found in start tags, only recognized when expressions are enabled on the tokenizer.
+	Expressions []RawExpression
+	Location
+
+	lazy            bool
+	attributesStart Location
+	attributesEnd   int
+}
+
+type RawAttribute struct {
+	Name           []rune
+	Atom           atom.Atom
+	Value          []rune
+	Quote          rune
+	NameLocation   Location
+	NameEnd        Location
+	EqualsLocation Location
+	ValueLocation  Location
+	ValueEnd       Location
+}
+
+type RawExpression struct {
+	Value []rune
+	// Attribute is the index of the attribute whose value contains the expression, -1 for expressions
+	// in place of an attribute.
+	Attribute int
+	Location
+}
+
+func (r *RawToken) reset() {
+	*r = RawToken{Attributes: r.Attributes[:0], Expressions: r.Expressions[:0]}
+}
+
+func (r *RawToken) illegal(code, reason string, location Location) {
+	r.Kind, r.Code, r.Reason, r.Location = "ILLEGAL", code, reason, location
+}
+
+// token converts the raw token to its allocated Token counterpart.
+func (t *Tokenizer) token(raw *RawToken) Token {
+	switch raw.Kind {
+	case "DOCTYPE":
+		return &Doctype{raw.HasSystem, raw.Location}
+	case "START_TAG":
+		tag := &StartTag{IsSelfClosing: raw.IsSelfClosing, Location: raw.Location}
+		tag.Atom, tag.Name = intern(raw.Name)
+		if raw.lazy {
+			lazy := &lazyAttributes{tokenizer: *t, end: raw.attributesEnd}
+			lazy.tokenizer.i, lazy.tokenizer.line, lazy.tokenizer.column = raw.attributesStart.Cursor, raw.attributesStart.Line, raw.attributesStart.Column
+			tag.lazy = lazy
+		} else {
+			tag.setAttributes(raw)
+		}
+		return tag
+	case "END_TAG":
+		tag := &EndTag{Location: raw.Location}
+		tag.Atom, tag.Name = intern(raw.Name)
+		return tag
+	case "TEXT":
+		return &Text{string(raw.Data), raw.Location}
+	case "EXPRESSION":
+		return &Expression{string(raw.Data), raw.Location}
+	case "ILLEGAL":
+		return &Illegal{Reason: raw.Reason, Location: raw.Location, Code: raw.Code}
+	}
+	return &Eof{raw.Location}
+}
+
+func (t *StartTag) setAttributes(raw *RawToken) {
+	attributes := make([]Attribute, len(raw.Attributes))
+	for i, attribute := range raw.Attributes {
+		attributes[i] = Attribute{
+			Atom:           attribute.Atom,
+			Value:          string(attribute.Value),
+			Quote:          attribute.Quote,
+			NameLocation:   attribute.NameLocation,
+			NameEnd:        attribute.NameEnd,
+			EqualsLocation: attribute.EqualsLocation,
+			ValueLocation:  attribute.ValueLocation,
+			ValueEnd:       attribute.ValueEnd,
+		}
+		_, attributes[i].Name = intern(attribute.Name)
+	}
+
+	for _, expression := range raw.Expressions {
+		converted := &Expression{string(expression.Value), expression.Location}
+		if expression.Attribute < 0 {
+			t.Expressions = append(t.Expressions, converted)
+		} else {
+			attributes[expression.Attribute].Expressions = append(attributes[expression.Attribute].Expressions, converted)
+		}
+	}
+
+	// As in browsers, an attribute repeating the name of an earlier one is dropped.
+	t.Attributes = make(map[string]Attribute, len(attributes))
+	for i, attribute := range attributes {
+		if !slices.ContainsFunc(raw.Attributes[:i], func(previous RawAttribute) bool { return equalFold(previous.Name, raw.Attributes[i].Name) }) {
+			t.Attributes[attribute.Name] = attribute
+		}
+	}
+}
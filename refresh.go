@@ -0,0 +1,95 @@
+package html
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Refresh is the redirect declared by `<meta http-equiv="refresh">`.
+type Refresh struct {
+	// Delay is the number of seconds to wait before refreshing.
+	Delay int
+	// URL is the target of the redirect, empty when the page refreshes itself.
+	URL string
+}
+
+// ParseMetaRefresh parses the content attribute of `<meta http-equiv="refresh">` following the forgiving grammar
+// of the HTML standard, e.g. `5; url='/next'`. Fractions of the delay are ignored, and the URL is returned as
+// written, to be resolved against the document's base URL.
+// https://html.spec.whatwg.org/multipage/semantics.html#shared-declarative-refresh-steps
+func ParseMetaRefresh(content string) (Refresh, bool) {
+	input := []rune(content)
+	position := 0
+
+	skipWhitespace := func() {
+		for position < len(input) && isWhitespace(input[position]) {
+			position++
+		}
+	}
+	consume := func(c rune) bool {
+		if position < len(input) && toLower(input[position]) == c {
+			position++
+			return true
+		}
+		return false
+	}
+
+	skipWhitespace()
+
+	start := position
+	for position < len(input) && isDigit(input[position]) {
+		position++
+	}
+	var refresh Refresh
+	if position == start {
+		if position >= len(input) || input[position] != '.' {
+			return Refresh{}, false
+		}
+	} else {
+		delay, err := strconv.Atoi(string(input[start:position]))
+		if err != nil {
+			return Refresh{}, false
+		}
+		refresh.Delay = delay
+	}
+
+	for position < len(input) && (isDigit(input[position]) || input[position] == '.') {
+		position++
+	}
+	if position >= len(input) {
+		return refresh, true
+	}
+
+	if c := input[position]; c != ';' && c != ',' && !isWhitespace(c) {
+		return Refresh{}, false
+	}
+	skipWhitespace()
+	if position < len(input) && (input[position] == ';' || input[position] == ',') {
+		position++
+	}
+	skipWhitespace()
+	if position >= len(input) {
+		return refresh, true
+	}
+
+	// As in the standard, a partially matched `url=` prefix is skipped as far as it matches.
+	if consume('u') && consume('r') && consume('l') {
+		skipWhitespace()
+		if consume('=') {
+			skipWhitespace()
+		}
+	}
+
+	var quote rune
+	if position < len(input) && (input[position] == '"' || input[position] == '\'') {
+		quote = input[position]
+		position++
+	}
+	target := string(input[position:])
+	if end := strings.IndexRune(target, quote); quote != 0 && end >= 0 {
+		target = target[:end]
+	}
+
+	refresh.URL = strings.TrimFunc(target, isWhitespace)
+	return refresh, true
+}
@@ -0,0 +1,43 @@
+package html
+
+import (
+	"testing"
+)
+
+func TestParseMetaRefresh(t *testing.T) {
+	tests := []struct {
+		content  string
+		expected Refresh
+		ok       bool
+	}{
+		{"5", Refresh{5, ""}, true},
+		{"  0; url=/next", Refresh{0, "/next"}, true},
+		{"3.5, URL = 'https://example.com/a b' trailing", Refresh{3, "https://example.com/a b"}, true},
+		{`10;"/quoted"`, Refresh{10, "/quoted"}, true},
+		{".5; url=/fraction", Refresh{0, "/fraction"}, true},
+		{"1 /no-prefix", Refresh{1, "/no-prefix"}, true},
+		{"1; urn:x", Refresh{1, "n:x"}, true},
+		{"2;", Refresh{2, ""}, true},
+		{"5 ", Refresh{5, ""}, true},
+		{"", Refresh{}, false},
+		{"soon; url=/x", Refresh{}, false},
+		{"5x; url=/x", Refresh{}, false},
+	}
+
+	for _, test := range tests {
+		refresh, ok := ParseMetaRefresh(test.content)
+		if ok != test.ok || refresh != test.expected {
+			t.Errorf("%q: got %+v, %v, expected %+v, %v", test.content, refresh, ok, test.expected, test.ok)
+		}
+	}
+}
+
+func TestExtractMetadataRefresh(t *testing.T) {
+	metadata, err := ExtractMetadata(Tokenize(`<meta http-equiv="refresh" content="nope"><meta http-equiv="Refresh" content="0; url=/moved">`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if metadata.Refresh == nil || *metadata.Refresh != (Refresh{0, "/moved"}) {
+		t.Errorf("unexpected refresh %+v", metadata.Refresh)
+	}
+}
@@ -0,0 +1,83 @@
+package html
+
+import "slices"
+
+// Handler receives the tokens of a template as events from Scan. Returning an error from any method stops
+// scanning, Scan returning that error.
+type Handler interface {
+	OnDoctype(*Doctype) error
+	OnStartTag(*StartTag) error
+	OnEndTag(*EndTag) error
+	OnText(*Text) error
+	OnExpression(*Expression) error
+	// OnComment receives the text between `<!--` and `-->` and the location of the comment.
+	OnComment(text string, location Location) error
+	// OnError receives illegal input, after which scanning continues unless an error is returned.
+	OnError(*Illegal) error
+}
+
+// BaseHandler implements every Handler method by doing nothing, to be embedded in handlers interested only in
+// some of the events.
+type BaseHandler struct{}
+
+func (BaseHandler) OnDoctype(*Doctype) error         { return nil }
+func (BaseHandler) OnStartTag(*StartTag) error       { return nil }
+func (BaseHandler) OnEndTag(*EndTag) error           { return nil }
+func (BaseHandler) OnText(*Text) error               { return nil }
+func (BaseHandler) OnExpression(*Expression) error   { return nil }
+func (BaseHandler) OnComment(string, Location) error { return nil }
+func (BaseHandler) OnError(*Illegal) error           { return nil }
+
+// Scan tokenizes the template, passing every token to the handler.
+func Scan(template string, handler Handler) error {
+	t := NewTokenizer(template)
+	return t.Scan(handler)
+}
+
+// Scan passes every remaining token to the handler, stopping at the end of input or at the first error returned by
+// the handler. Comments, which the tokenizer reports as illegal, are passed to OnComment instead of OnError.
+func (t *Tokenizer) Scan(handler Handler) error {
+	for {
+		start := t.i
+		var err error
+
+		switch token := t.next().(type) {
+		case *Eof:
+			return nil
+		case *Doctype:
+			err = handler.OnDoctype(token)
+		case *StartTag:
+			err = handler.OnStartTag(token)
+		case *EndTag:
+			err = handler.OnEndTag(token)
+		case *Text:
+			err = handler.OnText(token)
+		case *Expression:
+			err = handler.OnExpression(token)
+		case *Illegal:
+			if text, ok := t.comment(token, start); ok {
+				err = handler.OnComment(text, token.Location)
+			} else if err = handler.OnError(token); err == nil && t.i == start {
+				return nil
+			}
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// comment returns the text of the comment reported as the illegal token, which started at the given position.
+func (t *Tokenizer) comment(token *Illegal, start int) (string, bool) {
+	source := t.template[start:t.i]
+	if token.Code != CodeMarkupDeclaration || !slices.Equal(source[:min(4, len(source))], []rune("<!--")) {
+		return "", false
+	}
+
+	text := source[4:]
+	if len(text) >= 3 && slices.Equal(text[len(text)-3:], []rune("-->")) {
+		text = text[:len(text)-3]
+	}
+	return string(text), true
+}
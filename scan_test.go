@@ -0,0 +1,65 @@
+package html
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+type recordingHandler struct {
+	BaseHandler
+	events []string
+	stopAt string
+}
+
+func (h *recordingHandler) record(event string) error {
+	h.events = append(h.events, event)
+	if event == h.stopAt {
+		return errors.New("stop")
+	}
+	return nil
+}
+
+func (h *recordingHandler) OnStartTag(tag *StartTag) error { return h.record("start " + tag.Name) }
+func (h *recordingHandler) OnEndTag(tag *EndTag) error     { return h.record("end " + tag.Name) }
+func (h *recordingHandler) OnText(text *Text) error        { return h.record("text " + text.Value) }
+func (h *recordingHandler) OnError(illegal *Illegal) error {
+	return h.record("error " + illegal.Reason)
+}
+
+func (h *recordingHandler) OnComment(text string, location Location) error {
+	return h.record(fmt.Sprintf("comment %q @%d:%d", text, location.Line, location.Column))
+}
+
+func TestScan(t *testing.T) {
+	template := "<!DOCTYPE html><p>a<!-- note --><b id=x>b</b></p>"
+
+	handler := &recordingHandler{}
+	if err := Scan(template, handler); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"start p",
+		"text a",
+		`comment " note " @1:20`,
+		"error expected quotes in attribute definition",
+		"text x>b",
+		"end b",
+		"end p",
+	}
+	if !reflect.DeepEqual(handler.events, expected) {
+		t.Errorf("got %q, expected %q", handler.events, expected)
+	}
+}
+
+func TestScanStops(t *testing.T) {
+	handler := &recordingHandler{stopAt: "text a"}
+	if err := Scan("<p>a</p><p>b</p>", handler); err == nil || err.Error() != "stop" {
+		t.Errorf("expected the handler's error, got %v", err)
+	}
+	if len(handler.events) != 2 {
+		t.Errorf("expected scanning to stop, got %q", handler.events)
+	}
+}
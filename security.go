@@ -0,0 +1,117 @@
+package html
+
+import (
+	"iter"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// urlAttributes are the attributes holding URLs which may be navigated to or loaded.
+var urlAttributes = []string{"href", "src", "action", "formaction", "xlink:href", "poster", "data", "cite"}
+
+// AuditSecurity reports risky constructs for review without changing anything: inline event handlers, `javascript:`
+// URLs, inline scripts and styles without a nonce, forms submitting over plain HTTP and links opening a new window
+// without `noopener`. Diagnostics are warnings, coded "inline-event-handler", "javascript-url", "missing-nonce",
+// "insecure-form-action" and "unsafe-target-blank" respectively. Illegal tokens are skipped.
+func AuditSecurity(tokens iter.Seq[Token]) DiagnosticList {
+	var diagnostics DiagnosticList
+	report := func(r Range, code, message string) {
+		diagnostics = append(diagnostics, Diagnostic{Range: r, Severity: "warning", Code: code, Message: message})
+	}
+
+	for token := range tokens {
+		tag, ok := token.(*StartTag)
+		if !ok {
+			continue
+		}
+		name := strings.ToLower(tag.Name)
+
+		attributes := slices.SortedFunc(maps.Values(tag.Attributes), func(a, b Attribute) int {
+			return a.NameLocation.Cursor - b.NameLocation.Cursor
+		})
+		for _, attribute := range attributes {
+			key := strings.ToLower(attribute.Name)
+			switch {
+			case strings.HasPrefix(key, "on") && len(key) > 2:
+				report(Range{attribute.NameLocation, attribute.NameEnd}, "inline-event-handler", "inline event handler "+key+" on <"+name+">")
+			case slices.Contains(urlAttributes, key) && urlScheme(attribute.Value) == "javascript":
+				report(attribute.ValueRange(), "javascript-url", "javascript: URL in "+key+" of <"+name+">")
+			}
+		}
+
+		switch name {
+		case "script":
+			if _, external := tag.GetAttribute("src"); !external && isScriptType(tag) && !tag.HasAttribute("nonce") {
+				report(nameRange(tag.Location, tag.Name), "missing-nonce", "inline <script> without a nonce")
+			}
+		case "style":
+			if !tag.HasAttribute("nonce") {
+				report(nameRange(tag.Location, tag.Name), "missing-nonce", "inline <style> without a nonce")
+			}
+		}
+
+		for _, key := range []string{"action", "formaction"} {
+			if action, ok := tag.GetAttribute(key); ok && urlScheme(action.Value) == "http" {
+				report(action.ValueRange(), "insecure-form-action", "<"+name+"> submits to an insecure http:// URL")
+			}
+		}
+
+		if target, ok := tag.GetAttribute("target"); ok && strings.EqualFold(target.Value, "_blank") && (name == "a" || name == "area" || name == "form") {
+			rel, _ := tag.GetAttribute("rel")
+			tokens := ParseClassList(strings.ToLower(rel.Value))
+			if !slices.Contains(tokens, "noopener") && !slices.Contains(tokens, "noreferrer") {
+				report(Range{target.NameLocation, target.ValueEnd}, "unsafe-target-blank", `target="_blank" on <`+name+`> without rel="noopener"`)
+			}
+		}
+	}
+	return diagnostics
+}
+
+// isScriptType reports whether a script element holds a classic script or a module rather than a data block.
+// https://html.spec.whatwg.org/multipage/scripting.html#prepare-the-script-element
+func isScriptType(tag *StartTag) bool {
+	kind, ok := tag.GetAttribute("type")
+	if !ok {
+		language, ok := tag.GetAttribute("language")
+		return !ok || language.Value == "" || isJavaScriptMIMEType("text/"+language.Value)
+	}
+	value := strings.TrimFunc(kind.Value, isWhitespace)
+	return value == "" || strings.EqualFold(value, "module") || isJavaScriptMIMEType(value)
+}
+
+// https://mimesniff.spec.whatwg.org/#javascript-mime-type-essence-match
+func isJavaScriptMIMEType(value string) bool {
+	switch strings.ToLower(value) {
+	case "application/ecmascript", "application/javascript", "application/x-ecmascript", "application/x-javascript",
+		"text/ecmascript", "text/javascript", "text/javascript1.0", "text/javascript1.1", "text/javascript1.2",
+		"text/javascript1.3", "text/javascript1.4", "text/javascript1.5", "text/jscript", "text/livescript",
+		"text/x-ecmascript", "text/x-javascript":
+		return true
+	}
+	return false
+}
+
+// urlScheme returns the lowercase scheme of a URL the way browsers parse it, ignoring surrounding control
+// characters and spaces as well as tabs and newlines anywhere, empty for relative URLs.
+// https://url.spec.whatwg.org/#concept-basic-url-parser
+func urlScheme(value string) string {
+	value = strings.TrimFunc(value, func(c rune) bool { return c <= ' ' })
+	value = strings.Map(func(c rune) rune {
+		if c == '\t' || c == '\n' || c == '\r' {
+			return -1
+		}
+		return c
+	}, value)
+
+	scheme, _, ok := strings.Cut(value, ":")
+	if !ok || scheme == "" || !isLetter(rune(scheme[0])) {
+		return ""
+	}
+	for _, c := range scheme {
+		if !isLetter(c) && !isDigit(c) && c != '+' && c != '-' && c != '.' {
+			return ""
+		}
+	}
+	return strings.ToLower(scheme)
+}
@@ -0,0 +1,48 @@
+package html
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAuditSecurity(t *testing.T) {
+	template := `<button onclick="go()">Go</button>
+<a href=" JaVa	script:alert(1)" target="_blank">x</a>
+<a href="/safe" target="_BLANK" rel="external NoOpener">y</a>
+<script>inline()</script><script src="/app.js"></script><script nonce="r4nd0m">ok()</script>
+<script type="application/ld+json">{}</script><style>p {}</style>
+<form action="http://example.com/login"><button formaction="https://example.com/">Send</button></form>`
+
+	var got []string
+	for _, diagnostic := range AuditSecurity(Tokenize(template)) {
+		got = append(got, diagnostic.Code+" "+diagnostic.Error())
+	}
+
+	expected := []string{
+		"inline-event-handler 1:9: inline event handler onclick on <button>",
+		"javascript-url 2:10: javascript: URL in href of <a>",
+		"unsafe-target-blank 2:33: target=\"_blank\" on <a> without rel=\"noopener\"",
+		"missing-nonce 4:2: inline <script> without a nonce",
+		"missing-nonce 5:48: inline <style> without a nonce",
+		"insecure-form-action 6:15: <form> submits to an insecure http:// URL",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %q, expected %q", got, expected)
+	}
+}
+
+func TestURLScheme(t *testing.T) {
+	tests := map[string]string{
+		"\x01 javascript:void(0)": "javascript",
+		"java\nscript:x":          "javascript",
+		"HTTP://example.com":      "http",
+		"/path:with-colon":        "",
+		"./a:b":                   "",
+		"relative":                "",
+	}
+	for value, expected := range tests {
+		if got := urlScheme(value); got != expected {
+			t.Errorf("%q: got %q, expected %q", value, got, expected)
+		}
+	}
+}
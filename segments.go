@@ -0,0 +1,195 @@
+package html
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var inlineElements = []string{
+	"a", "abbr", "b", "bdi", "bdo", "br", "cite", "code", "data", "dfn", "em", "i", "kbd", "mark", "q", "s",
+	"samp", "small", "span", "strong", "sub", "sup", "time", "u", "var", "wbr",
+}
+
+// TextSegment is a run of translatable text. Inline markup inside the run is replaced by numbered placeholders
+// `{0}`, `{1}`, ... and literal braces are doubled.
+type TextSegment struct {
+	Text string
+	// Placeholders holds the source markup of every placeholder, indexed by its number.
+	Placeholders []string
+	// Start and End delimit the segment in the source, End being exclusive.
+	Start Location
+	End   Location
+}
+
+// ExtractSegments splits the text content of a template into translatable segments. Block-level markup ends a
+// segment, whitespace around segments is not part of them and the contents of scripts and styles are skipped.
+func ExtractSegments(template string) ([]TextSegment, error) {
+	source := []rune(template)
+
+	var segments []TextSegment
+	var pieces []segmentPiece
+	skip := false
+
+	flush := func(end Location) {
+		if segment, ok := newTextSegment(source, pieces, end); ok {
+			segments = append(segments, segment)
+		}
+		pieces = nil
+	}
+
+	t := NewTokenizer(template)
+	for token := t.next(); ; token = t.next() {
+		switch token := token.(type) {
+		case *Illegal:
+			if token.Code != CodeMarkupDeclaration {
+				return segments, token
+			}
+		case *Eof:
+			flush(token.Location)
+			return segments, nil
+		case *Text:
+			if !skip {
+				pieces = append(pieces, segmentPiece{token.Location, t.location(), false})
+			}
+		case *StartTag:
+			name := strings.ToLower(token.Name)
+			if slices.Contains(inlineElements, name) {
+				pieces = append(pieces, segmentPiece{token.Location, t.location(), true})
+				continue
+			}
+			flush(token.Location)
+			skip = (name == "script" || name == "style") && !token.IsSelfClosing
+		case *EndTag:
+			if slices.Contains(inlineElements, strings.ToLower(token.Name)) {
+				pieces = append(pieces, segmentPiece{token.Location, t.location(), true})
+				continue
+			}
+			flush(token.Location)
+			skip = false
+		case *Doctype:
+			flush(token.Location)
+		}
+	}
+}
+
+// InjectSegments replaces every segment in the template with its translation, restoring the placeholders to the
+// original markup. Translations are matched to segments by index.
+func InjectSegments(template string, segments []TextSegment, translations []string) (string, error) {
+	if len(segments) != len(translations) {
+		return "", fmt.Errorf("expected %d translations, got %d", len(segments), len(translations))
+	}
+
+	source := []rune(template)
+	order := make([]int, len(segments))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortFunc(order, func(a, b int) int {
+		return segments[a].Start.Cursor - segments[b].Start.Cursor
+	})
+
+	var b strings.Builder
+	cursor := 0
+	for _, i := range order {
+		segment := segments[i]
+		if segment.Start.Cursor < cursor || segment.End.Cursor > len(source) || segment.End.Cursor < segment.Start.Cursor {
+			return "", fmt.Errorf("segment %d is out of range or overlaps another segment", i)
+		}
+
+		text, err := segment.restore(translations[i])
+		if err != nil {
+			return "", fmt.Errorf("translation %d: %w", i, err)
+		}
+
+		b.WriteString(string(source[cursor:segment.Start.Cursor]))
+		b.WriteString(text)
+		cursor = segment.End.Cursor
+	}
+	b.WriteString(string(source[cursor:]))
+
+	return b.String(), nil
+}
+
+func (s TextSegment) restore(translation string) (string, error) {
+	var b strings.Builder
+	input := []rune(translation)
+
+	for i := 0; i < len(input); i++ {
+		switch c := input[i]; {
+		case c == '{' && i+1 < len(input) && input[i+1] == '{', c == '}' && i+1 < len(input) && input[i+1] == '}':
+			b.WriteRune(c)
+			i++
+		case c == '{':
+			end := slices.Index(input[i:], '}')
+			if end < 0 {
+				return "", fmt.Errorf("unterminated placeholder at %d", i)
+			}
+			index, err := strconv.Atoi(string(input[i+1 : i+end]))
+			if err != nil || index < 0 || index >= len(s.Placeholders) {
+				return "", fmt.Errorf("unknown placeholder %q", string(input[i:i+end+1]))
+			}
+			b.WriteString(s.Placeholders[index])
+			i += end
+		default:
+			b.WriteRune(c)
+		}
+	}
+
+	return b.String(), nil
+}
+
+type segmentPiece struct {
+	start, end Location
+	markup     bool
+}
+
+func newTextSegment(source []rune, pieces []segmentPiece, end Location) (TextSegment, bool) {
+	hasText := false
+	for _, piece := range pieces {
+		if !piece.markup && strings.TrimFunc(string(source[piece.start.Cursor:piece.end.Cursor]), isWhitespace) != "" {
+			hasText = true
+		}
+	}
+	if !hasText {
+		return TextSegment{}, false
+	}
+
+	segment := TextSegment{Start: pieces[0].start, End: end}
+	for segment.Start.Cursor < end.Cursor && isWhitespace(source[segment.Start.Cursor]) {
+		segment.Start = advanceLocation(segment.Start, source[segment.Start.Cursor])
+	}
+	segment.End = segment.Start
+	for _, c := range strings.TrimRightFunc(string(source[segment.Start.Cursor:end.Cursor]), isWhitespace) {
+		segment.End = advanceLocation(segment.End, c)
+	}
+
+	var b strings.Builder
+	for _, piece := range pieces {
+		start, stop := max(piece.start.Cursor, segment.Start.Cursor), min(piece.end.Cursor, segment.End.Cursor)
+		if start >= stop {
+			continue
+		}
+		if piece.markup {
+			b.WriteString("{" + strconv.Itoa(len(segment.Placeholders)) + "}")
+			segment.Placeholders = append(segment.Placeholders, string(source[start:stop]))
+			continue
+		}
+		text := string(source[start:stop])
+		b.WriteString(strings.NewReplacer("{", "{{", "}", "}}").Replace(text))
+	}
+	segment.Text = b.String()
+
+	return segment, true
+}
+
+func advanceLocation(location Location, c rune) Location {
+	location.Cursor++
+	location.Column++
+	if c == '\n' {
+		location.Line++
+		location.Column = 1
+	}
+	return location
+}
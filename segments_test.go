@@ -0,0 +1,53 @@
+package html
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestExtractSegments(t *testing.T) {
+	template := `<div>
+	<p>Hello, <b>{name}</b>!</p>
+	<script>var skipped = "text";</script>
+	<p>Read the <a href="/docs">docs</a>.
+	</p>
+</div><!-- end -->`
+
+	segments, err := ExtractSegments(template)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(segments) != 2 {
+		t.Fatalf("expected 2 segments, got %d", len(segments))
+	}
+
+	first := segments[0]
+	if first.Text != "Hello, {0}{{name}}{1}!" || !slices.Equal(first.Placeholders, []string{"<b>", "</b>"}) {
+		t.Errorf("unexpected first segment: %+v", first)
+	}
+	if first.Start != (Location{Line: 2, Column: 5, Cursor: 10}) || first.End != (Location{Line: 2, Column: 26, Cursor: 31}) {
+		t.Errorf("unexpected first segment range: %+v - %+v", first.Start, first.End)
+	}
+	if segments[1].Text != "Read the {0}docs{1}." || segments[1].End.Line != 4 {
+		t.Errorf("unexpected second segment: %+v", segments[1])
+	}
+
+	translated, err := InjectSegments(template, segments, []string{"Cześć, {0}{{name}}{1}!", "Przeczytaj {0}dokumentację{1}."})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `<div>
+	<p>Cześć, <b>{name}</b>!</p>
+	<script>var skipped = "text";</script>
+	<p>Przeczytaj <a href="/docs">dokumentację</a>.
+	</p>
+</div><!-- end -->`
+	if translated != expected {
+		t.Errorf("expected %s, got %s", expected, translated)
+	}
+
+	if _, err := InjectSegments(template, segments, []string{"{2}", ""}); err == nil {
+		t.Error("expected an error for an unknown placeholder")
+	}
+}
@@ -0,0 +1,399 @@
+package html
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Selector is a compiled list of CSS selectors matched against start tags. It is immutable once compiled, so it may
+// be shared between goroutines.
+type Selector struct {
+	source       string
+	alternatives []complexSelector
+}
+
+// complexSelector is a sequence of compound selectors separated by combinators, such as `nav > ul a`.
+type complexSelector struct {
+	compounds []compoundSelector
+	// combinators[i] is ' ' or '>', combining compounds[i] and compounds[i+1].
+	combinators []byte
+}
+
+// compoundSelector is a sequence of simple selectors all of which must match, such as `a.external[href]`.
+type compoundSelector struct {
+	// name is the lowercase type selector, empty for the universal selector or none.
+	name       string
+	id         string
+	classes    []string
+	attributes []attributeSelector
+	// root tells whether the element must not have a parent, as for `:root`.
+	root bool
+	// nthChild is the position the element must have among its siblings, as for `:nth-child(3)`, 0 if any.
+	nthChild int
+}
+
+// attributeSelector is `[name]` when operator is empty, or `[name operator "value"]`.
+type attributeSelector struct {
+	name     string
+	operator string
+	value    string
+}
+
+// CompileSelector compiles a comma-separated list of selectors made of type, universal, id, class and attribute
+// selectors, `:root` and `:nth-child` with a plain position, combined with descendant and child combinators, e.g.
+// `a[href^="https:"], article > img.lazy, ul > li:nth-child(2)`. Type and attribute names are matched ignoring case,
+// as in HTML documents.
+// https://www.w3.org/TR/selectors-4/#complex
+func CompileSelector(selector string) (*Selector, error) {
+	p := &selectorParser{source: selector}
+	compiled := &Selector{source: selector}
+	for {
+		p.skipWhitespace()
+		var complex complexSelector
+		for {
+			compound, err := p.compound()
+			if err != nil {
+				return nil, err
+			}
+			complex.compounds = append(complex.compounds, compound)
+
+			start := p.i
+			p.skipWhitespace()
+			if p.consume('>') {
+				p.skipWhitespace()
+				complex.combinators = append(complex.combinators, '>')
+			} else if p.i > start && !p.done() && p.current() != ',' {
+				complex.combinators = append(complex.combinators, ' ')
+			} else {
+				break
+			}
+		}
+		compiled.alternatives = append(compiled.alternatives, complex)
+
+		if p.done() {
+			return compiled, nil
+		}
+		if !p.consume(',') {
+			return nil, p.errorf("unexpected %q", p.current())
+		}
+	}
+}
+
+// MustCompileSelector is like CompileSelector but panics if the selector cannot be compiled.
+func MustCompileSelector(selector string) *Selector {
+	compiled, err := CompileSelector(selector)
+	if err != nil {
+		panic(err)
+	}
+	return compiled
+}
+
+// String returns the source of the selector.
+func (s *Selector) String() string {
+	return s.source
+}
+
+// Match reports whether the start tag matches any of the selectors in the list, without regard to its ancestors, so
+// selectors with combinators never match. Attributes of tags whose attributes are not parsed yet never match.
+func (s *Selector) Match(tag *StartTag) bool {
+	return s.MatchWithin(tag, nil)
+}
+
+// MatchWithin reports whether the start tag matches any of the selectors in the list given the start tags of its
+// open ancestors, the parent last. A tag without ancestors matches `:root`, while `:nth-child` never matches, as the
+// positions of the tags among their siblings are unknown; Select knows them.
+func (s *Selector) MatchWithin(tag *StartTag, ancestors []*StartTag) bool {
+	return s.matchWithin(tag, ancestors, nil)
+}
+
+// matchWithin is like MatchWithin, positions holding the positions of the ancestors and of the tag among their
+// siblings, or nil if they are unknown.
+func (s *Selector) matchWithin(tag *StartTag, ancestors []*StartTag, positions []int) bool {
+	return slices.ContainsFunc(s.alternatives, func(complex complexSelector) bool {
+		return complex.match(len(complex.compounds)-1, tag, ancestors, positions)
+	})
+}
+
+// match matches the compounds up to i right to left, the last against the tag and the others against its ancestors.
+func (c complexSelector) match(i int, tag *StartTag, ancestors []*StartTag, positions []int) bool {
+	position := 0
+	if positions != nil {
+		position = positions[len(ancestors)]
+	}
+	if !c.compounds[i].match(tag, len(ancestors) == 0, position) {
+		return false
+	}
+	if i == 0 {
+		return true
+	}
+
+	if c.combinators[i-1] == '>' {
+		return len(ancestors) > 0 && c.match(i-1, ancestors[len(ancestors)-1], ancestors[:len(ancestors)-1], positions)
+	}
+	for j := len(ancestors) - 1; j >= 0; j-- {
+		if c.match(i-1, ancestors[j], ancestors[:j], positions) {
+			return true
+		}
+	}
+	return false
+}
+
+// Select returns the start tags matching the selector, keeping a stack of open elements to evaluate combinators, so
+// that memory use depends only on how deeply elements nest. An end tag closes the innermost open element of the same
+// name along with the elements opened after it, void and self-closing elements never being open.
+func Select(tokens iter.Seq[Token], selector *Selector) iter.Seq[*StartTag] {
+	return func(yield func(*StartTag) bool) {
+		var open []*StartTag
+		// positions holds the positions of the open elements among their siblings, and children the number of
+		// children seen so far of the document and of each open element.
+		var positions []int
+		children := []int{0}
+		for token := range tokens {
+			switch token := token.(type) {
+			case *StartTag:
+				children[len(open)]++
+				position := children[len(open)]
+				if selector.matchWithin(token, open, append(positions, position)) && !yield(token) {
+					return
+				}
+				if !token.IsSelfClosing && !isVoidElement(strings.ToLower(token.Name)) {
+					open, positions, children = append(open, token), append(positions, position), append(children, 0)
+				}
+			case *EndTag:
+				for i := len(open) - 1; i >= 0; i-- {
+					if strings.EqualFold(open[i].Name, token.Name) {
+						open, positions, children = open[:i], positions[:i], children[:i+1]
+						break
+					}
+				}
+			}
+		}
+	}
+}
+
+// match matches the tag, which is at the top of the document if root is set and at the given position among its
+// siblings, 0 if unknown.
+func (c compoundSelector) match(tag *StartTag, root bool, position int) bool {
+	if c.name != "" && !strings.EqualFold(c.name, tag.Name) {
+		return false
+	}
+	if c.root && !root || c.nthChild != 0 && c.nthChild != position {
+		return false
+	}
+	if c.id != "" && tag.ID() != c.id {
+		return false
+	}
+	if len(c.classes) > 0 {
+		classes := tag.Classes()
+		for _, class := range c.classes {
+			if !slices.Contains(classes, class) {
+				return false
+			}
+		}
+	}
+	for _, selector := range c.attributes {
+		attribute, ok := tag.GetAttribute(selector.name)
+		if !ok || !selector.match(attribute.Value) {
+			return false
+		}
+	}
+	return true
+}
+
+// https://www.w3.org/TR/selectors-4/#attribute-selectors
+func (a attributeSelector) match(value string) bool {
+	switch a.operator {
+	case "=":
+		return value == a.value
+	case "~=":
+		return slices.Contains(strings.FieldsFunc(value, isWhitespace), a.value)
+	case "|=":
+		return value == a.value || strings.HasPrefix(value, a.value+"-")
+	case "^=":
+		return a.value != "" && strings.HasPrefix(value, a.value)
+	case "$=":
+		return a.value != "" && strings.HasSuffix(value, a.value)
+	case "*=":
+		return a.value != "" && strings.Contains(value, a.value)
+	}
+	return true
+}
+
+type selectorParser struct {
+	source string
+	i      int
+}
+
+func (p *selectorParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("selector %q at %d: %s", p.source, p.i, fmt.Sprintf(format, args...))
+}
+
+func (p *selectorParser) done() bool {
+	return p.i >= len(p.source)
+}
+
+func (p *selectorParser) current() rune {
+	if p.done() {
+		return 0
+	}
+	c, _ := utf8.DecodeRuneInString(p.source[p.i:])
+	return c
+}
+
+func (p *selectorParser) advance() {
+	_, size := utf8.DecodeRuneInString(p.source[p.i:])
+	p.i += size
+}
+
+func (p *selectorParser) consume(c rune) bool {
+	if p.current() == c {
+		p.advance()
+		return true
+	}
+	return false
+}
+
+func (p *selectorParser) skipWhitespace() {
+	for isWhitespace(p.current()) {
+		p.advance()
+	}
+}
+
+func (p *selectorParser) compound() (compoundSelector, error) {
+	var compound compoundSelector
+	start := p.i
+
+	if !p.consume('*') && isIdentifierStart(p.current()) {
+		compound.name = strings.ToLower(p.identifier())
+	}
+
+	for {
+		var err error
+		switch {
+		case p.consume('#'):
+			if compound.id, err = p.requireIdentifier("id"); err != nil {
+				return compound, err
+			}
+		case p.consume('.'):
+			var class string
+			if class, err = p.requireIdentifier("class name"); err != nil {
+				return compound, err
+			}
+			compound.classes = append(compound.classes, class)
+		case p.consume('['):
+			var attribute attributeSelector
+			if attribute, err = p.attribute(); err != nil {
+				return compound, err
+			}
+			compound.attributes = append(compound.attributes, attribute)
+		case p.consume(':'):
+			if err = p.pseudoClass(&compound); err != nil {
+				return compound, err
+			}
+		default:
+			if p.i == start {
+				return compound, p.errorf("expected a selector")
+			}
+			return compound, nil
+		}
+	}
+}
+
+// pseudoClass parses `root` or `nth-child(n)` after a colon.
+func (p *selectorParser) pseudoClass(compound *compoundSelector) error {
+	name := strings.ToLower(p.identifier())
+	switch {
+	case name == "root":
+		compound.root = true
+		return nil
+	case name == "nth-child" && p.consume('('):
+		p.skipWhitespace()
+		start := p.i
+		for isDigit(p.current()) {
+			p.advance()
+		}
+		position, err := strconv.Atoi(p.source[start:p.i])
+		if err != nil || position == 0 {
+			return p.errorf("expected a position in `:nth-child`")
+		}
+		p.skipWhitespace()
+		if !p.consume(')') {
+			return p.errorf("expected `)`")
+		}
+		compound.nthChild = position
+		return nil
+	}
+	return p.errorf("unsupported pseudo-class %q", name)
+}
+
+func (p *selectorParser) attribute() (attributeSelector, error) {
+	var attribute attributeSelector
+	var err error
+
+	p.skipWhitespace()
+	if attribute.name, err = p.requireIdentifier("attribute name"); err != nil {
+		return attribute, err
+	}
+	p.skipWhitespace()
+
+	if p.consume(']') {
+		return attribute, nil
+	}
+	for _, operator := range []string{"=", "~=", "|=", "^=", "$=", "*="} {
+		if strings.HasPrefix(p.source[p.i:], operator) {
+			attribute.operator = operator
+			p.i += len(operator)
+			break
+		}
+	}
+	if attribute.operator == "" {
+		return attribute, p.errorf("expected an attribute operator or `]`")
+	}
+
+	p.skipWhitespace()
+	if quote := p.current(); quote == '"' || quote == '\'' {
+		end := strings.IndexRune(p.source[p.i+1:], quote)
+		if end < 0 {
+			return attribute, p.errorf("unterminated string")
+		}
+		attribute.value = p.source[p.i+1 : p.i+1+end]
+		p.i += end + 2
+	} else if attribute.value, err = p.requireIdentifier("attribute value"); err != nil {
+		return attribute, err
+	}
+
+	p.skipWhitespace()
+	if !p.consume(']') {
+		return attribute, p.errorf("expected `]`")
+	}
+	return attribute, nil
+}
+
+func (p *selectorParser) requireIdentifier(what string) (string, error) {
+	if !isIdentifierCharacter(p.current()) {
+		return "", p.errorf("expected %s", what)
+	}
+	return p.identifier(), nil
+}
+
+func (p *selectorParser) identifier() string {
+	start := p.i
+	for isIdentifierCharacter(p.current()) {
+		p.advance()
+	}
+	return p.source[start:p.i]
+}
+
+// https://www.w3.org/TR/css-syntax-3/#ident-start-code-point
+func isIdentifierStart(c rune) bool {
+	return isLetter(c) || c == '_' || c >= utf8.RuneSelf
+}
+
+// https://www.w3.org/TR/css-syntax-3/#ident-code-point
+func isIdentifierCharacter(c rune) bool {
+	return isIdentifierStart(c) || isDigit(c) || c == '-'
+}
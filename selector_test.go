@@ -0,0 +1,97 @@
+package html
+
+import (
+	"slices"
+	"testing"
+)
+
+func firstStartTag(template string) *StartTag {
+	for token := range Tokenize(template) {
+		if tag, ok := token.(*StartTag); ok {
+			return tag
+		}
+	}
+	return nil
+}
+
+func TestSelectorMatch(t *testing.T) {
+	tests := []struct {
+		selector string
+		template string
+		match    bool
+	}{
+		{"a", `<A href="/">`, true},
+		{"*", `<custom-element>`, true},
+		{"#main", `<div id="main">`, true},
+		{"#main", `<div id="Main">`, false},
+		{".card.active", `<div class="active  card">`, true},
+		{".card.active", `<div class="card">`, false},
+		{"input[disabled]", `<input DISABLED>`, true},
+		{`a[href^="https:"]`, `<a href="https://example.com">`, true},
+		{`a[href$='.pdf']`, `<a href="/doc.pdf">`, true},
+		{`a[href*=example]`, `<a href="https://example.com">`, true},
+		{`a[href^=""]`, `<a href="/">`, false},
+		{`[rel~=noopener]`, `<a rel="noopener noreferrer">`, true},
+		{`[lang|=en]`, `<p lang="en-GB">`, true},
+		{`[lang|=en]`, `<p lang="english">`, false},
+		{`[type=text]`, `<input type="text">`, true},
+		{"p, img.lazy", `<img class="lazy">`, true},
+		{"p, img.lazy", `<img>`, false},
+		{"p:root", `<p>`, true},
+		{"p:nth-child(1)", `<p>`, false},
+	}
+
+	for _, test := range tests {
+		selector, err := CompileSelector(test.selector)
+		if err != nil {
+			t.Errorf("%s: %v", test.selector, err)
+			continue
+		}
+		if match := selector.Match(firstStartTag(test.template)); match != test.match {
+			t.Errorf("%s against %s: got %v", test.selector, test.template, match)
+		}
+	}
+}
+
+func TestCompileSelectorErrors(t *testing.T) {
+	for _, selector := range []string{"", "a,", ".", "#", "[href", "[href=]", `[href="x]`, "[href!=x]", "a:hover", "li:nth-child(0)", "li:nth-child(2n)", "li:nth-child(1", "p, , a", "a >", "> a", "a + b"} {
+		if _, err := CompileSelector(selector); err == nil {
+			t.Errorf("%q: expected an error", selector)
+		}
+	}
+}
+
+func TestSelect(t *testing.T) {
+	template := `<article id="a"><p><img src="1"><span><img src="2"/></span></p></article>
+<div><article><img src="3"></article><img src="4"></div><section><p><IMG src="5"></P></section>`
+
+	tests := map[string][]string{
+		"article img":         {"1", "2", "3"},
+		"article > img":       {"3"},
+		"p > img, span > img": {"1", "2", "5"},
+		"#a p img":            {"1", "2"},
+		"div img":             {"3", "4"},
+		"section   >p >img":   {"5"},
+		"img":                 {"1", "2", "3", "4", "5"},
+		"img:nth-child(2)":    {"4"},
+		"p > :nth-child(1)":   {"1", "5"},
+		"div:root > img":      {"4"},
+		"img:root":            {},
+	}
+
+	for selector, expected := range tests {
+		var got []string
+		for tag := range Select(Tokenize(template), MustCompileSelector(selector)) {
+			got = append(got, tag.Attributes["src"].Value)
+		}
+		if !slices.Equal(got, expected) {
+			t.Errorf("%s: got %v, expected %v", selector, got, expected)
+		}
+	}
+}
+
+func TestMatchWithCombinators(t *testing.T) {
+	if MustCompileSelector("div p").Match(firstStartTag("<p>")) {
+		t.Error("expected selectors with combinators not to match without ancestors")
+	}
+}
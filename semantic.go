@@ -0,0 +1,107 @@
+package html
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+// SemanticToken classifies a span of the source for syntax highlighting. Class is one of "doctype", "tag",
+// "attribute", "value", "text", "expression", "comment" or "illegal".
+type SemanticToken struct {
+	Class string
+	Range
+}
+
+// SemanticTokens returns an iterator over the classified spans of the remaining input in source order. Tag names,
+// attribute names and values are classified separately, leaving angle brackets, equals signs and whitespace
+// between them unclassified. Quoted values include their quotes and are split around the expressions inside them.
+// Comments are classified although the tokenizer reports them as illegal.
+func (t *Tokenizer) SemanticTokens() iter.Seq[SemanticToken] {
+	return func(yield func(SemanticToken) bool) {
+		var raw RawToken
+		var tag []SemanticToken
+
+		for {
+			start := t.location()
+			t.NextInto(&raw)
+			end := t.location()
+
+			var token SemanticToken
+			switch raw.Kind {
+			case "EOF":
+				return
+			case "DOCTYPE":
+				token = SemanticToken{"doctype", Range{start, end}}
+			case "TEXT":
+				token = SemanticToken{"text", Range{start, end}}
+			case "EXPRESSION":
+				token = SemanticToken{"expression", Range{start, end}}
+			case "ILLEGAL":
+				if end.Cursor == start.Cursor {
+					return
+				}
+				token = SemanticToken{"illegal", Range{start, end}}
+				if slices.Equal(t.template[start.Cursor:min(start.Cursor+4, end.Cursor)], []rune("<!--")) {
+					token.Class = "comment"
+				}
+			case "END_TAG":
+				token = SemanticToken{"tag", t.span(t.span(start, 2).End, len(raw.Name))}
+			case "START_TAG":
+				tag = t.semanticTag(&raw, start, tag[:0])
+				for _, token := range tag {
+					if !yield(token) {
+						return
+					}
+				}
+				continue
+			}
+
+			if !yield(token) {
+				return
+			}
+		}
+	}
+}
+
+// semanticTag appends the classified spans of a start tag to tokens.
+func (t *Tokenizer) semanticTag(raw *RawToken, start Location, tokens []SemanticToken) []SemanticToken {
+	tokens = append(tokens, SemanticToken{"tag", t.span(t.span(start, 1).End, len(raw.Name))})
+
+	for i, attribute := range raw.Attributes {
+		tokens = append(tokens, SemanticToken{"attribute", Range{attribute.NameLocation, attribute.NameEnd}})
+		if attribute.Quote == 0 {
+			continue
+		}
+
+		value := Range{attribute.ValueLocation, attribute.ValueEnd}
+		for _, expression := range raw.Expressions {
+			if expression.Attribute != i {
+				continue
+			}
+			if expression.Cursor > value.Start.Cursor {
+				tokens = append(tokens, SemanticToken{"value", Range{value.Start, expression.Location}})
+			}
+			value.Start = t.span(expression.Location, len(t.left)+len(expression.Value)+len(t.right)).End
+		}
+		if value.End.Cursor > value.Start.Cursor {
+			tokens = append(tokens, SemanticToken{"value", value})
+		}
+	}
+
+	for _, expression := range raw.Expressions {
+		tokens = append(tokens, SemanticToken{"expression", t.span(expression.Location, len(t.left)+len(expression.Value)+len(t.right))})
+	}
+
+	slices.SortFunc(tokens, func(a, b SemanticToken) int { return cmp.Compare(a.Start.Cursor, b.Start.Cursor) })
+	return tokens
+}
+
+// span returns the range of the given number of runes from start.
+func (t *Tokenizer) span(start Location, length int) Range {
+	end := start
+	for _, c := range t.template[start.Cursor : start.Cursor+length] {
+		end = advanceLocation(end, c)
+	}
+	return Range{start, end}
+}
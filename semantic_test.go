@@ -0,0 +1,54 @@
+package html
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSemanticTokens(t *testing.T) {
+	template := "<!DOCTYPE html><a href=\"/x/{{ id }}\" {{ attrs }} hidden>Hi {{ name }}</a><!-- note -->"
+
+	tokenizer := NewTokenizer(template)
+	tokenizer.EnableExpressions(DefaultDelimiters)
+
+	var got []string
+	for token := range tokenizer.SemanticTokens() {
+		got = append(got, token.Class+" "+template[token.Start.Cursor:token.End.Cursor])
+	}
+
+	expected := []string{
+		"doctype <!DOCTYPE html>",
+		"tag a",
+		"attribute href",
+		"value \"/x/",
+		"expression {{ id }}",
+		"value \"",
+		"expression {{ attrs }}",
+		"attribute hidden",
+		"text Hi ",
+		"expression {{ name }}",
+		"tag a",
+		"comment <!-- note -->",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %q, expected %q", got, expected)
+	}
+}
+
+func TestSemanticTokensLocations(t *testing.T) {
+	tokenizer := NewTokenizer("<p\n  class=\"a\nb\">")
+
+	var got []SemanticToken
+	for token := range tokenizer.SemanticTokens() {
+		got = append(got, token)
+	}
+
+	expected := []SemanticToken{
+		{"tag", Range{Location{1, 2, 1}, Location{1, 3, 2}}},
+		{"attribute", Range{Location{2, 3, 5}, Location{2, 8, 10}}},
+		{"value", Range{Location{2, 9, 11}, Location{3, 3, 16}}},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %+v, expected %+v", got, expected)
+	}
+}
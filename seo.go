@@ -0,0 +1,109 @@
+package html
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+)
+
+// AuditSEO checks a page for problems affecting how search engines index it, reporting warnings coded:
+//   - "missing-title", "empty-title" and "duplicate-title" for the `<title>`;
+//   - "missing-description" for a missing or empty `<meta name="description">`;
+//   - "multiple-h1" for every `<h1>` after the first;
+//   - "missing-canonical" for a missing `<link rel="canonical">`;
+//   - "image-dimensions" for `<img>` without both width and height, which shifts the layout as images load;
+//   - "noindex-conflict" for a robots noindex contradicted by an index directive or a canonical link.
+//
+// Problems with the page as a whole are reported at its `<head>`, or at the start of the page if it has none.
+// Illegal tokens are skipped.
+func AuditSEO(tokens iter.Seq[Token]) DiagnosticList {
+	var diagnostics DiagnosticList
+	report := func(r Range, code, message string) {
+		diagnostics = append(diagnostics, Diagnostic{Range: r, Severity: "warning", Code: code, Message: message})
+	}
+
+	page := Range{Location{Line: 1, Column: 1}, Location{Line: 1, Column: 1}}
+	var title *StartTag
+	var titleText strings.Builder
+	var canonical, noindex, index *StartTag
+	inTitle, seenHead, description, h1 := false, false, false, 0
+
+	for token := range tokens {
+		switch token := token.(type) {
+		case *Text:
+			if inTitle {
+				titleText.WriteString(token.Value)
+			}
+		case *EndTag:
+			if inTitle && strings.EqualFold(token.Name, "title") {
+				inTitle = false
+				if strings.TrimFunc(titleText.String(), isWhitespace) == "" {
+					report(nameRange(title.Location, title.Name), "empty-title", "<title> is empty")
+				}
+			}
+		case *StartTag:
+			name := strings.ToLower(token.Name)
+			switch name {
+			case "head":
+				if !seenHead {
+					seenHead, page = true, nameRange(token.Location, token.Name)
+				}
+			case "title":
+				if title != nil {
+					report(nameRange(token.Location, token.Name), "duplicate-title", fmt.Sprintf("duplicate <title>, first at %d:%d", title.Line, title.Column))
+					continue
+				}
+				title, inTitle = token, !token.IsSelfClosing
+			case "h1":
+				if h1++; h1 > 1 {
+					report(nameRange(token.Location, token.Name), "multiple-h1", "more than one <h1> on the page")
+				}
+			case "img":
+				if !token.HasAttribute("width") || !token.HasAttribute("height") {
+					report(nameRange(token.Location, token.Name), "image-dimensions", "<img> without width and height")
+				}
+			case "link":
+				rel, _ := token.GetAttribute("rel")
+				if slices.Contains(ParseClassList(strings.ToLower(rel.Value)), "canonical") && canonical == nil {
+					canonical = token
+				}
+			case "meta":
+				metaName, _ := token.GetAttribute("name")
+				content, _ := token.GetAttribute("content")
+				switch strings.ToLower(metaName.Value) {
+				case "description":
+					description = description || strings.TrimFunc(content.Value, isWhitespace) != ""
+				case "robots", "googlebot":
+					for _, directive := range strings.Split(strings.ToLower(content.Value), ",") {
+						switch strings.TrimFunc(directive, isWhitespace) {
+						case "noindex", "none":
+							noindex = token
+						case "index":
+							index = token
+						}
+					}
+				}
+			}
+		}
+	}
+
+	if title == nil {
+		report(page, "missing-title", "the page has no <title>")
+	}
+	if !description {
+		report(page, "missing-description", `the page has no <meta name="description">`)
+	}
+	if canonical == nil {
+		report(page, "missing-canonical", `the page has no <link rel="canonical">`)
+	}
+	if noindex != nil && index != nil {
+		report(nameRange(noindex.Location, noindex.Name), "noindex-conflict", fmt.Sprintf("noindex contradicts the index directive at %d:%d", index.Line, index.Column))
+	}
+	if noindex != nil && canonical != nil {
+		report(nameRange(noindex.Location, noindex.Name), "noindex-conflict", fmt.Sprintf("noindex contradicts the canonical link at %d:%d", canonical.Line, canonical.Column))
+	}
+
+	SortDiagnostics(diagnostics)
+	return diagnostics
+}
@@ -0,0 +1,48 @@
+package html
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAuditSEO(t *testing.T) {
+	template := `<html><head>
+<title> </title><title>Second</title>
+<meta name="robots" content="noindex, follow"><meta name="googlebot" content="index">
+<link rel="canonical" href="https://example.com/">
+</head><body><h1>A</h1><h1>B</h1><img src="a.png" width="10"><img src="b.png" width="10" height="10"></body></html>`
+
+	var got []string
+	for _, diagnostic := range AuditSEO(Tokenize(template)) {
+		got = append(got, diagnostic.Code+" "+diagnostic.Error())
+	}
+
+	expected := []string{
+		"missing-description 1:8: the page has no <meta name=\"description\">",
+		"empty-title 2:2: <title> is empty",
+		"duplicate-title 2:18: duplicate <title>, first at 2:1",
+		"noindex-conflict 3:2: noindex contradicts the index directive at 3:47",
+		"noindex-conflict 3:2: noindex contradicts the canonical link at 4:1",
+		"multiple-h1 5:25: more than one <h1> on the page",
+		"image-dimensions 5:35: <img> without width and height",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %q, expected %q", got, expected)
+	}
+}
+
+func TestAuditSEOEmptyPage(t *testing.T) {
+	var got []string
+	for _, diagnostic := range AuditSEO(Tokenize("<p>text</p>")) {
+		got = append(got, diagnostic.Code+" "+diagnostic.Error())
+	}
+
+	expected := []string{
+		"missing-title 1:1: the page has no <title>",
+		"missing-description 1:1: the page has no <meta name=\"description\">",
+		"missing-canonical 1:1: the page has no <link rel=\"canonical\">",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %q, expected %q", got, expected)
+	}
+}
@@ -0,0 +1,103 @@
+package html
+
+import (
+	"slices"
+	"strings"
+)
+
+// DocumentStats summarizes a document, e.g. for page weight budgets.
+type DocumentStats struct {
+	// Tags counts start tags by lowercase name.
+	Tags map[string]int
+	// Attributes counts the attributes of all start tags.
+	Attributes int
+	// MaxDepth is the deepest nesting of elements, 1 for elements at the top of the document.
+	MaxDepth int
+	// TextBytes is the length of the text, excluding the contents of scripts and styles, and MarkupBytes the length
+	// of everything else.
+	TextBytes   int
+	MarkupBytes int
+	// Resources counts the URLs of subresources loaded by the page by kind: "script", "stylesheet", "image",
+	// "media", "frame" and "object". `data:` URLs are not counted.
+	Resources map[string]int
+}
+
+// TextRatio returns the share of text in the document, between 0 and 1.
+func (s DocumentStats) TextRatio() float64 {
+	if s.TextBytes+s.MarkupBytes == 0 {
+		return 0
+	}
+	return float64(s.TextBytes) / float64(s.TextBytes+s.MarkupBytes)
+}
+
+// Stats tokenizes the template and summarizes it. Illegal constructs count as markup. Elements are nested as the
+// tokens suggest, so an end tag closes the innermost open element of the same name along with the elements opened
+// after it.
+func Stats(template string) DocumentStats {
+	stats := DocumentStats{Tags: map[string]int{}, Resources: map[string]int{}}
+	var open []string
+
+	resource := func(kind, url string) {
+		if url = strings.TrimFunc(url, isWhitespace); url != "" && urlScheme(url) != "data" {
+			stats.Resources[kind]++
+		}
+	}
+
+	for token := range Tokenize(template) {
+		switch token := token.(type) {
+		case *Text:
+			if len(open) == 0 || open[len(open)-1] != "script" && open[len(open)-1] != "style" {
+				stats.TextBytes += len(token.Value)
+			}
+		case *EndTag:
+			name := strings.ToLower(token.Name)
+			for i := len(open) - 1; i >= 0; i-- {
+				if open[i] == name {
+					open = open[:i]
+					break
+				}
+			}
+		case *StartTag:
+			name := strings.ToLower(token.Name)
+			stats.Tags[name]++
+			stats.Attributes += len(token.Attributes)
+			if !token.IsSelfClosing && !isVoidElement(name) {
+				open = append(open, name)
+				stats.MaxDepth = max(stats.MaxDepth, len(open))
+			} else {
+				stats.MaxDepth = max(stats.MaxDepth, len(open)+1)
+			}
+
+			src, _ := token.GetAttribute("src")
+			switch name {
+			case "script":
+				resource("script", src.Value)
+			case "img":
+				resource("image", src.Value)
+				if srcset, ok := token.GetAttribute("srcset"); ok {
+					candidates, _ := ParseSrcset(srcset.Value)
+					for _, candidate := range candidates {
+						resource("image", candidate.URL)
+					}
+				}
+			case "video", "audio", "source", "track":
+				resource("media", src.Value)
+			case "iframe", "frame":
+				resource("frame", src.Value)
+			case "embed":
+				resource("object", src.Value)
+			case "object":
+				data, _ := token.GetAttribute("data")
+				resource("object", data.Value)
+			case "link":
+				rel, _ := token.GetAttribute("rel")
+				if href, _ := token.GetAttribute("href"); slices.Contains(ParseClassList(strings.ToLower(rel.Value)), "stylesheet") {
+					resource("stylesheet", href.Value)
+				}
+			}
+		}
+	}
+
+	stats.MarkupBytes = len(template) - stats.TextBytes
+	return stats
+}
@@ -0,0 +1,32 @@
+package html
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+	template := `<html><head><link rel="Stylesheet" href="a.css"><script src="a.js"></script><style>p{}</style></head>
+<body><div class="a"><p>héllo <img src="a.png" srcset="b.png 2x, data:image/png;base64,AA 3x"><br/></p></div>
+<video src="v.mp4"></video><iframe src="/embed"></iframe><img src="data:image/gif;base64,AA"></body></html>`
+
+	stats := Stats(template)
+
+	tags := map[string]int{"html": 1, "head": 1, "link": 1, "script": 1, "style": 1, "body": 1, "div": 1, "p": 1, "img": 2, "br": 1, "video": 1, "iframe": 1}
+	if !maps.Equal(stats.Tags, tags) {
+		t.Errorf("got tags %v, expected %v", stats.Tags, tags)
+	}
+	resources := map[string]int{"stylesheet": 1, "script": 1, "image": 2, "media": 1, "frame": 1}
+	if !maps.Equal(stats.Resources, resources) {
+		t.Errorf("got resources %v, expected %v", stats.Resources, resources)
+	}
+	if stats.Attributes != 9 || stats.MaxDepth != 5 {
+		t.Errorf("unexpected attribute count %d or depth %d", stats.Attributes, stats.MaxDepth)
+	}
+	if stats.TextBytes != len("\n")+len("héllo ")+len("\n") || stats.TextBytes+stats.MarkupBytes != len(template) {
+		t.Errorf("unexpected byte counts %d and %d", stats.TextBytes, stats.MarkupBytes)
+	}
+	if ratio := stats.TextRatio(); ratio <= 0 || ratio >= 0.1 {
+		t.Errorf("unexpected text ratio %v", ratio)
+	}
+}
@@ -0,0 +1,137 @@
+package html
+
+import (
+	"errors"
+	"slices"
+	"time"
+	"unicode/utf8"
+)
+
+// Stream tokenizes input written to it in chunks, such as a response passing through a proxy. Tokens are passed to
+// emit as soon as no further input can change them, constructs cut by the end of a chunk being buffered until the
+// chunk completing them is written. The whole input is kept, as tokens and locations refer to it.
+type Stream struct {
+	tokenizer Tokenizer
+	pending   []byte
+	emit      func(Token) error
+	err       error
+}
+
+// Stream returns a stream tokenizing the remaining input followed by the input written to the stream, with the
+// options of the tokenizer.
+func (t *Tokenizer) Stream(emit func(Token) error) *Stream {
+	return &Stream{tokenizer: *t, emit: emit}
+}
+
+// Write appends p to the input and emits the tokens it completes. Bytes of a UTF-8 sequence cut by the end of p are
+// held until the rest of the sequence is written. The first error returned by emit is returned by every later call.
+// Raw text, such as a long script, is scanned once however many writes it spans, while a buffered tag or text
+// outside raw text elements is tokenized again from its start on each write.
+func (s *Stream) Write(p []byte) (int, error) {
+	if s.err != nil {
+		return 0, s.err
+	}
+
+	s.pending = append(s.pending, p...)
+	complete := len(s.pending)
+	for start := complete - 1; start >= max(complete-utf8.UTFMax, 0); start-- {
+		if utf8.RuneStart(s.pending[start]) {
+			if !utf8.FullRune(s.pending[start:]) {
+				complete = start
+			}
+			break
+		}
+	}
+
+	s.tokenizer.template = append(s.tokenizer.template, []rune(string(s.pending[:complete]))...)
+	s.pending = slices.Delete(s.pending, 0, complete)
+
+	for s.err == nil {
+		before := s.tokenizer
+		token := s.tokenizer.nextComplete()
+		if token == nil {
+			scanned := s.tokenizer.rawTextScanned
+			s.tokenizer = before
+			s.tokenizer.rawTextScanned = scanned
+			break
+		}
+		s.err = s.emit(token)
+	}
+	return len(p), s.err
+}
+
+// Flush emits buffered text up to the last `<`, which may start a tag, instead of holding it back until more input
+// shows where it ends. Text is then split across several tokens. Buffered tags are never emitted before they are
+// complete, and neither is text when expressions are enabled, as expressions may contain `<`.
+func (s *Stream) Flush() error {
+	if s.err != nil || len(s.tokenizer.left) > 0 {
+		return s.err
+	}
+
+	var raw RawToken
+	peek := s.tokenizer
+	peek.trace, peek.metrics = nil, nil
+	if peek.NextInto(&raw); raw.Kind != "TEXT" {
+		return nil
+	}
+
+	end := len(s.tokenizer.template)
+	if i := slices.Index(s.tokenizer.template[s.tokenizer.i:], '<'); i >= 0 {
+		end = s.tokenizer.i + i
+	}
+	if end == s.tokenizer.i {
+		return nil
+	}
+
+	// Raw text cut short must continue as raw text.
+	rawText, template := s.tokenizer.rawText, s.tokenizer.template
+	s.tokenizer.template = template[:end]
+	s.tokenizer.NextInto(&raw)
+	token := s.tokenizer.token(&raw)
+	s.tokenizer.template, s.tokenizer.rawText = template, rawText
+
+	s.err = s.emit(token)
+	return s.err
+}
+
+// Close ends the input, emitting the remaining tokens. Incomplete UTF-8 sequences held by Write are replaced by
+// U+FFFD.
+func (s *Stream) Close() error {
+	if s.err != nil {
+		return s.err
+	}
+
+	s.tokenizer.template = append(s.tokenizer.template, []rune(string(s.pending))...)
+	s.pending = nil
+	for token := range s.tokenizer.All() {
+		if s.err = s.emit(token); s.err != nil {
+			return s.err
+		}
+	}
+	s.err = errClosedStream
+	return nil
+}
+
+var errClosedStream = errors.New("write to closed stream")
+
+// nextComplete returns the next token, or nil when more input could change it. Only complete tokens are traced and
+// count towards the metrics, as incomplete ones are read again.
+func (t *Tokenizer) nextComplete() Token {
+	var raw RawToken
+	trace, metrics := t.trace, t.metrics
+	start, rawText, since := t.location(), t.rawText, time.Now()
+	t.trace, t.metrics, t.reachedEnd = nil, nil, false
+	t.NextInto(&raw)
+	if t.trace, t.metrics = trace, metrics; t.reachedEnd {
+		return nil
+	}
+
+	token := t.token(&raw)
+	if trace != nil {
+		t.traceToken(token, start, rawText)
+	}
+	if metrics != nil {
+		t.record(&raw, start.Cursor, since)
+	}
+	return token
+}
@@ -0,0 +1,123 @@
+package html
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func streamTokens(t *testing.T, chunks []string, expressions bool) []Token {
+	tokenizer := NewTokenizer("")
+	if expressions {
+		tokenizer.EnableExpressions(DefaultDelimiters)
+	}
+
+	var tokens []Token
+	stream := tokenizer.Stream(func(token Token) error {
+		tokens = append(tokens, token)
+		return nil
+	})
+	for _, chunk := range chunks {
+		if _, err := stream.Write([]byte(chunk)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := stream.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return tokens
+}
+
+func TestStreamSplits(t *testing.T) {
+	for _, template := range []string{
+		"<!DOCTYPE html SYSTEM 'about:legacy-compat'>\n<p class=\"a\">日本語 & text</p>",
+		"<script>if (a < b) {}</script><style>p {}</style><br/>",
+		"<div title=\"{{ a }}\" {{ b }}>{{ \"}}\" }} text</div><!-- comment -->",
+		"<div id=x>broken</div><p",
+		"<script>a{{ \"</script>\" }}{b}</script>",
+	} {
+		tokenizer := NewTokenizer(template)
+		tokenizer.EnableExpressions(DefaultDelimiters)
+		var expected []Token
+		for token := range tokenizer.All() {
+			expected = append(expected, token)
+		}
+
+		for i := range len(template) + 1 {
+			got := streamTokens(t, []string{template[:i], template[i:]}, true)
+			if !reflect.DeepEqual(got, expected) {
+				t.Errorf("%q split at %d:\ngot %v\nexpected %v", template, i, got, expected)
+			}
+		}
+	}
+}
+
+func TestStreamRawTextWrites(t *testing.T) {
+	template := "<script>\nif (a </b) {}\n</scrip </Script\n></script><p>"
+	var expected []Token
+	for token := range Tokenize(template) {
+		expected = append(expected, token)
+	}
+
+	// Writing a byte at a time resumes the scan of the script on every write.
+	if got := streamTokens(t, strings.Split(template, ""), false); !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %v, expected %v", got, expected)
+	}
+}
+
+func TestStreamRawTextExpressionWrites(t *testing.T) {
+	template := "<script>a{{ \"</script>\" }}{b}</script><p>"
+	tokenizer := NewTokenizer(template)
+	tokenizer.EnableExpressions(DefaultDelimiters)
+	var expected []Token
+	for token := range tokenizer.All() {
+		expected = append(expected, token)
+	}
+
+	if got := streamTokens(t, strings.Split(template, ""), true); !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %v, expected %v", got, expected)
+	}
+}
+
+func TestStreamEmitsCompleteTokens(t *testing.T) {
+	var kinds []string
+	tokenizer := NewTokenizer("")
+	stream := tokenizer.Stream(func(token Token) error {
+		kinds = append(kinds, token.Kind())
+		return nil
+	})
+
+	for _, chunk := range []string{"<div>text", "</di", "v><p"} {
+		stream.Write([]byte(chunk))
+		kinds = append(kinds, "|")
+	}
+
+	expected := []string{"START_TAG", "|", "TEXT", "|", "END_TAG", "|"}
+	if !reflect.DeepEqual(kinds, expected) {
+		t.Errorf("got %v, expected %v", kinds, expected)
+	}
+}
+
+func TestStreamFlush(t *testing.T) {
+	var got []string
+	tokenizer := NewTokenizer("")
+	stream := tokenizer.Stream(func(token Token) error {
+		s := token.(fmt.Stringer).String()
+		got = append(got, s[:strings.LastIndex(s, " @")])
+		return nil
+	})
+
+	for _, chunk := range []string{"<p>Hello", " world <", "b>!</b><script>a", "b</scr", "ipt>"} {
+		stream.Write([]byte(chunk))
+		if err := stream.Flush(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	stream.Close()
+
+	expected := []string{`<p>`, `"Hello"`, `" world "`, `<b>`, `"!"`, `</b>`, `<script>`, `"a"`, `"b"`, `</script>`}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %q, expected %q", got, expected)
+	}
+}
@@ -0,0 +1,66 @@
+package html
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// at formats the position of a token for its String method.
+func at(location Location) string {
+	return fmt.Sprintf(" @%d:%d", location.Line, location.Column)
+}
+
+func (t *Doctype) String() string {
+	if t.HasSystem {
+		return `<!DOCTYPE html SYSTEM "about:legacy-compat">` + at(t.Location)
+	}
+	return "<!DOCTYPE html>" + at(t.Location)
+}
+
+// String formats the tag for debugging with its attributes sorted by name, followed by expressions in place of
+// attributes and attributes which have not been parsed yet. Expressions are always written between `{{` and `}}`,
+// whatever the delimiters of the tokenizer, so the result is not meant to be tokenized again.
+func (t *StartTag) String() string {
+	var b strings.Builder
+	b.WriteString("<" + t.Name)
+	for _, name := range slices.Sorted(maps.Keys(t.Attributes)) {
+		b.WriteString(" " + name)
+		if value := t.Attributes[name].Value; value != "" {
+			fmt.Fprintf(&b, "=%q", value)
+		}
+	}
+	for _, expression := range t.Expressions {
+		b.WriteString(" {{" + expression.Value + "}}")
+	}
+	if raw := t.RawAttributes(); raw != "" {
+		b.WriteString(" " + raw)
+	}
+	if t.IsSelfClosing {
+		b.WriteString("/")
+	}
+	b.WriteString(">")
+	return b.String() + at(t.Location)
+}
+
+func (t *EndTag) String() string {
+	return "</" + t.Name + ">" + at(t.Location)
+}
+
+func (t *Text) String() string {
+	return fmt.Sprintf("%q", t.Value) + at(t.Location)
+}
+
+// String formats the expression for debugging, always between `{{` and `}}` whatever the delimiters of the tokenizer.
+func (t *Expression) String() string {
+	return "{{" + t.Value + "}}" + at(t.Location)
+}
+
+func (t *Illegal) String() string {
+	return "illegal: " + t.Reason + at(t.Location)
+}
+
+func (t *Eof) String() string {
+	return "EOF" + at(t.Location)
+}
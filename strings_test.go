@@ -0,0 +1,49 @@
+package html
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestTokenStrings(t *testing.T) {
+	template := "<!DOCTYPE html>\n<div id=\"x\" class='a \"b\"' hidden {{ attrs }}>Hi\n{{ name }}<br/></div><p id=x>"
+
+	tokenizer := NewTokenizer(template)
+	tokenizer.EnableExpressions(DefaultDelimiters)
+
+	var got []string
+	for token := range tokenizer.All() {
+		// Illegal implements error, which fmt prefers over String.
+		got = append(got, token.(fmt.Stringer).String())
+		if token.Kind() == "ILLEGAL" {
+			break
+		}
+	}
+
+	expected := []string{
+		`<!DOCTYPE html> @1:1`,
+		`"\n" @1:16`,
+		`<div class="a \"b\"" hidden id="x" {{ attrs }}> @2:1`,
+		`"Hi\n" @2:46`,
+		`{{ name }} @3:1`,
+		`<br/> @3:11`,
+		`</div> @3:16`,
+		`illegal: expected quotes in attribute definition @3:28`,
+	}
+	for i := range max(len(got), len(expected)) {
+		if i >= len(got) || i >= len(expected) || got[i] != expected[i] {
+			t.Fatalf("got %q, expected %q", got, expected)
+		}
+	}
+}
+
+func TestLazyStartTagString(t *testing.T) {
+	tokenizer := NewTokenizer(`<a href="/x" title='t'>`)
+	tokenizer.EnableLazyAttributes()
+
+	for token := range tokenizer.All() {
+		if s := fmt.Sprint(token); s != `<a href="/x" title='t'> @1:1` {
+			t.Errorf("unexpected string %q", s)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package html
+
+import (
+	"strings"
+)
+
+// Declaration is a property and its value in a `style` attribute.
+type Declaration struct {
+	// Property is lowercase, except for custom properties which are case-sensitive.
+	Property  string
+	Value     string
+	Important bool
+}
+
+// Style is the list of declarations of a `style` attribute, in source order.
+type Style []Declaration
+
+// ParseStyle parses the value of a `style` attribute as a CSS declaration list. Comments are dropped, semicolons
+// and `!important` inside strings, parentheses and brackets are left alone, and declarations without a property
+// or a colon are skipped, as browsers do.
+func ParseStyle(value string) Style {
+	var style Style
+	for _, source := range splitDeclarations(value) {
+		property, value, ok := strings.Cut(source, ":")
+		property = strings.TrimFunc(property, isWhitespace)
+		if !ok || property == "" || strings.ContainsFunc(property, isWhitespace) {
+			continue
+		}
+		declaration := Declaration{Property: normalizeProperty(property), Value: strings.TrimFunc(value, isWhitespace)}
+		if i := strings.LastIndexByte(declaration.Value, '!'); i >= 0 &&
+			strings.EqualFold(strings.TrimFunc(declaration.Value[i+1:], isWhitespace), "important") {
+			declaration.Value, declaration.Important = strings.TrimFunc(declaration.Value[:i], isWhitespace), true
+		}
+		style = append(style, declaration)
+	}
+	return style
+}
+
+// splitDeclarations splits a declaration list at top-level semicolons, removing comments.
+func splitDeclarations(value string) []string {
+	var declarations []string
+	var current strings.Builder
+	var quote rune
+	depth := 0
+
+	input := []rune(value)
+	for i := 0; i < len(input); i++ {
+		c := input[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && i+1 < len(input) {
+				current.WriteRune(c)
+				i++
+				c = input[i]
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '/' && i+1 < len(input) && input[i+1] == '*':
+			end := i + 2
+			for end+1 < len(input) && (input[end] != '*' || input[end+1] != '/') {
+				end++
+			}
+			i = end + 1
+			continue
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '(' || c == '[' || c == '{':
+			depth++
+		case c == ')' || c == ']' || c == '}':
+			depth = max(depth-1, 0)
+		case c == ';' && depth == 0:
+			declarations = append(declarations, current.String())
+			current.Reset()
+			continue
+		}
+		current.WriteRune(c)
+	}
+	return append(declarations, current.String())
+}
+
+// Get returns the declaration of a property which takes effect, the last one unless an earlier one is important.
+func (s Style) Get(property string) (Declaration, bool) {
+	property = normalizeProperty(property)
+
+	var found Declaration
+	ok := false
+	for _, declaration := range s {
+		if declaration.Property == property && (!found.Important || declaration.Important) {
+			found, ok = declaration, true
+		}
+	}
+	return found, ok
+}
+
+// Set replaces all declarations of a property with a single one, kept in place of the first of them, or appended.
+func (s Style) Set(property, value string, important bool) Style {
+	declaration := Declaration{normalizeProperty(property), value, important}
+	for i := range s {
+		if s[i].Property == declaration.Property {
+			s[i] = declaration
+			return append(s[:i+1], s[i+1:].Remove(property)...)
+		}
+	}
+	return append(s, declaration)
+}
+
+// Remove removes all declarations of a property.
+func (s Style) Remove(property string) Style {
+	property = normalizeProperty(property)
+
+	kept := s[:0]
+	for _, declaration := range s {
+		if declaration.Property != property {
+			kept = append(kept, declaration)
+		}
+	}
+	return kept
+}
+
+// String serializes the declarations as the value of a `style` attribute, e.g. `color: red; margin: 0 !important`.
+func (s Style) String() string {
+	var b strings.Builder
+	for i, declaration := range s {
+		if i > 0 {
+			b.WriteString("; ")
+		}
+		b.WriteString(declaration.Property + ": " + declaration.Value)
+		if declaration.Important {
+			b.WriteString(" !important")
+		}
+	}
+	return b.String()
+}
+
+func normalizeProperty(property string) string {
+	if strings.HasPrefix(property, "--") {
+		return property
+	}
+	return strings.ToLower(property)
+}
@@ -0,0 +1,48 @@
+package html
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseStyle(t *testing.T) {
+	style := ParseStyle(` COLOR: Red ; /* comment; with semicolon */ background: url("a;b.png") no-repeat;
+		margin:0!IMPORTANT; --Custom-Prop: {a;b}; invalid; : empty; font-family: 'x;y', sans-serif /* trailing */`)
+
+	expected := Style{
+		{"color", "Red", false},
+		{"background", `url("a;b.png") no-repeat`, false},
+		{"margin", "0", true},
+		{"--Custom-Prop", "{a;b}", false},
+		{"font-family", "'x;y', sans-serif", false},
+	}
+	if !reflect.DeepEqual(style, expected) {
+		t.Errorf("got %+v, expected %+v", style, expected)
+	}
+
+	if got := ParseStyle("a: 1 /* unterminated ; b: 2"); !reflect.DeepEqual(got, Style{{"a", "1", false}}) {
+		t.Errorf("unexpected style %+v", got)
+	}
+	if got := ParseStyle("a: 1 /*/ b: 2 */; c: 3"); !reflect.DeepEqual(got, Style{{"a", "1", false}, {"c", "3", false}}) {
+		t.Errorf("unexpected style %+v", got)
+	}
+}
+
+func TestStyleEditing(t *testing.T) {
+	style := ParseStyle("color: red; margin: 0 !important; color: blue; margin: 1px")
+
+	if color, _ := style.Get("Color"); color.Value != "blue" {
+		t.Errorf("expected the last declaration to win, got %+v", color)
+	}
+	if margin, _ := style.Get("margin"); margin.Value != "0" {
+		t.Errorf("expected the important declaration to win, got %+v", margin)
+	}
+	if _, ok := style.Get("padding"); ok {
+		t.Error("unexpected padding declaration")
+	}
+
+	style = style.Set("color", "green", false).Remove("margin").Set("padding", "2px", true)
+	if s := style.String(); s != "color: green; padding: 2px !important" {
+		t.Errorf("unexpected serialization %q", s)
+	}
+}
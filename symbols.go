@@ -0,0 +1,157 @@
+package html
+
+import (
+	"strings"
+)
+
+// Range delimits a part of the source, End being exclusive.
+type Range struct {
+	Start Location
+	End   Location
+}
+
+// Symbol is an element worth listing in an outline of the document: a heading, a form, a custom element or any
+// element with an id.
+type Symbol struct {
+	// Name is the text of a heading, or the element name followed by `#id` for other elements with an id.
+	Name string
+	// Element is the lowercase element name.
+	Element string
+	// Kind is one of "heading", "form", "component" or "element".
+	Kind string
+	// Range spans the element from its start tag through its end tag, while SelectionRange spans its name in the
+	// start tag.
+	Range          Range
+	SelectionRange Range
+	Children       []*Symbol
+}
+
+// DocumentSymbols builds the hierarchy of symbols in a template, each symbol nesting under the closest enclosing
+// symbol. Elements left unclosed end where the end tag of an ancestor or the end of input closes them.
+func DocumentSymbols(template string) ([]*Symbol, error) {
+	type open struct {
+		name   string
+		symbol *Symbol
+	}
+
+	var (
+		roots   []*Symbol
+		stack   []open
+		heading *Symbol
+		text    strings.Builder
+	)
+
+	parent := func() *Symbol {
+		for i := len(stack) - 1; i >= 0; i-- {
+			if stack[i].symbol != nil {
+				return stack[i].symbol
+			}
+		}
+		return nil
+	}
+
+	closeSymbol := func(symbol *Symbol, end Location) {
+		symbol.Range.End = end
+		if symbol == heading {
+			if name := strings.Join(strings.FieldsFunc(text.String(), isWhitespace), " "); name != "" {
+				symbol.Name = name
+			}
+			heading = nil
+		}
+	}
+
+	t := NewTokenizer(template)
+	for token := t.next(); ; token = t.next() {
+		switch token := token.(type) {
+		case *Illegal:
+			if token.Code != CodeMarkupDeclaration {
+				return roots, token
+			}
+		case *Eof:
+			for i := len(stack) - 1; i >= 0; i-- {
+				if stack[i].symbol != nil {
+					closeSymbol(stack[i].symbol, token.Location)
+				}
+			}
+			return roots, nil
+		case *Text:
+			if heading != nil {
+				text.WriteString(token.Value)
+			}
+		case *StartTag:
+			name := strings.ToLower(token.Name)
+			symbol := newSymbol(token, name)
+			if symbol != nil {
+				symbol.Range.Start = token.Location
+				if p := parent(); p != nil {
+					p.Children = append(p.Children, symbol)
+				} else {
+					roots = append(roots, symbol)
+				}
+			}
+
+			if token.IsSelfClosing || isVoidElement(name) {
+				if symbol != nil {
+					symbol.Range.End = t.location()
+				}
+				continue
+			}
+
+			if symbol != nil && symbol.Kind == "heading" && heading == nil {
+				heading = symbol
+				text.Reset()
+			}
+			stack = append(stack, open{name, symbol})
+		case *EndTag:
+			name := strings.ToLower(token.Name)
+			for i := len(stack) - 1; i >= 0; i-- {
+				if stack[i].name != name {
+					continue
+				}
+				for j := len(stack) - 1; j > i; j-- {
+					if stack[j].symbol != nil {
+						closeSymbol(stack[j].symbol, token.Location)
+					}
+				}
+				if stack[i].symbol != nil {
+					closeSymbol(stack[i].symbol, t.location())
+				}
+				stack = stack[:i]
+				break
+			}
+		}
+	}
+}
+
+// newSymbol returns the symbol of a start tag, or nil for elements which are not symbols.
+func newSymbol(token *StartTag, name string) *Symbol {
+	symbol := &Symbol{Name: name, Element: name, Kind: "element"}
+	if id := token.ID(); id != "" {
+		symbol.Name += "#" + id
+	}
+
+	switch {
+	case headingLevel(name) != 0:
+		symbol.Kind = "heading"
+	case name == "form":
+		symbol.Kind = "form"
+	case strings.Contains(name, "-"):
+		symbol.Kind = "component"
+	case token.ID() == "":
+		return nil
+	}
+
+	symbol.SelectionRange = nameRange(token.Location, token.Name)
+	return symbol
+}
+
+// nameRange returns the range of the name of a tag starting at the location.
+func nameRange(location Location, name string) Range {
+	start := location
+	start.Cursor++
+	start.Column++
+	end := start
+	end.Cursor += len([]rune(name))
+	end.Column += len([]rune(name))
+	return Range{start, end}
+}
@@ -0,0 +1,64 @@
+package html
+
+import (
+	"testing"
+)
+
+func TestDocumentSymbols(t *testing.T) {
+	template := `<main id="content">
+	<h1>Hello
+		<em>world</em></h1>
+	<form action="/search">
+		<input ID="query" name="q">
+		<my-button>Go</my-button>
+	</form>
+	<section><p id="unclosed">text</section>
+</main><!-- end -->`
+
+	symbols, err := DocumentSymbols(template)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(symbols) != 1 {
+		t.Fatalf("expected a single root symbol, got %d", len(symbols))
+	}
+
+	main := symbols[0]
+	if main.Name != "main#content" || main.Kind != "element" {
+		t.Errorf("unexpected root symbol %q of kind %q", main.Name, main.Kind)
+	}
+	if main.Range != (Range{Location{1, 1, 0}, Location{9, 8, len([]rune(template)) - len("<!-- end -->")}}) {
+		t.Errorf("unexpected root range %+v", main.Range)
+	}
+	if main.SelectionRange != (Range{Location{1, 2, 1}, Location{1, 6, 5}}) {
+		t.Errorf("unexpected root selection range %+v", main.SelectionRange)
+	}
+
+	expected := []struct{ name, kind string }{
+		{"Hello world", "heading"},
+		{"form", "form"},
+		{"p#unclosed", "element"},
+	}
+	if len(main.Children) != len(expected) {
+		t.Fatalf("expected %d children, got %d", len(expected), len(main.Children))
+	}
+	for i, child := range main.Children {
+		if child.Name != expected[i].name || child.Kind != expected[i].kind {
+			t.Errorf("child %d: got %q of kind %q, expected %q of kind %q", i, child.Name, child.Kind, expected[i].name, expected[i].kind)
+		}
+	}
+
+	form := main.Children[1]
+	if len(form.Children) != 2 || form.Children[0].Name != "input#query" || form.Children[1].Kind != "component" {
+		t.Errorf("unexpected form children %+v", form.Children)
+	}
+	if input := form.Children[0]; input.Range.End.Line != 5 || input.Range.End.Column != 30 {
+		t.Errorf("void element should end after its start tag, got %+v", input.Range.End)
+	}
+
+	unclosed := main.Children[2]
+	if unclosed.Range.End.Line != 8 || unclosed.Range.End.Column != 32 {
+		t.Errorf("unclosed element should end at the end tag of its ancestor, got %+v", unclosed.Range.End)
+	}
+}
@@ -0,0 +1,195 @@
+package html
+
+import (
+	"encoding/csv"
+	"io"
+	"iter"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ExtractTables converts every `<table>` in the token stream into rows of cell texts, in document order of the
+// opening tags. Cells spanning several columns or rows are repeated in every slot they cover and all rows of a
+// table are padded to the same width.
+// https://html.spec.whatwg.org/multipage/tables.html#forming-a-table
+func ExtractTables(tokens iter.Seq[Token]) ([][][]string, error) {
+	var tables [][][]string
+	var stack []*tableBuilder
+
+	for token := range tokens {
+		var table *tableBuilder
+		if len(stack) > 0 {
+			table = stack[len(stack)-1]
+		}
+
+		switch token := token.(type) {
+		case *Illegal:
+			if token.Code != CodeMarkupDeclaration {
+				return tables, token
+			}
+		case *Text:
+			if table != nil && table.cell != nil {
+				table.cell.text.WriteString(token.Value)
+			}
+		case *StartTag:
+			name := strings.ToLower(token.Name)
+			if name == "table" && !token.IsSelfClosing {
+				tables = append(tables, nil)
+				stack = append(stack, &tableBuilder{index: len(tables) - 1})
+				continue
+			}
+			if table == nil {
+				continue
+			}
+
+			switch name {
+			case "thead", "tbody", "tfoot":
+				table.endRow()
+				table.spans = nil
+			case "tr":
+				table.endRow()
+				table.startRow()
+			case "td", "th":
+				table.endCell()
+				if table.row == nil {
+					table.startRow()
+				}
+				table.cell = &tableCell{
+					colspan: spanAttribute(token, "colspan", 1, 1000),
+					rowspan: spanAttribute(token, "rowspan", 0, 65534),
+				}
+			}
+		case *EndTag:
+			if table == nil {
+				continue
+			}
+
+			switch strings.ToLower(token.Name) {
+			case "table":
+				table.endRow()
+				tables[table.index] = table.finish()
+				stack = stack[:len(stack)-1]
+			case "thead", "tbody", "tfoot":
+				table.endRow()
+				table.spans = nil
+			case "tr":
+				table.endRow()
+			case "td", "th":
+				table.endCell()
+			}
+		}
+	}
+
+	for _, table := range stack {
+		table.endRow()
+		tables[table.index] = table.finish()
+	}
+
+	return tables, nil
+}
+
+// WriteCSV writes the rows of a table in CSV format.
+func WriteCSV(w io.Writer, rows [][]string) error {
+	writer := csv.NewWriter(w)
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+	return writer.Error()
+}
+
+type tableBuilder struct {
+	index  int
+	rows   [][]string
+	row    []string
+	filled []bool
+	cell   *tableCell
+	spans  []tableSpan
+}
+
+type tableCell struct {
+	text    strings.Builder
+	colspan int
+	rowspan int
+}
+
+type tableSpan struct {
+	remaining int
+	value     string
+}
+
+func (t *tableBuilder) startRow() {
+	t.row, t.filled = []string{}, []bool{}
+	for column, span := range t.spans {
+		if span.remaining > 0 {
+			t.set(column, span.value)
+			t.spans[column].remaining--
+		}
+	}
+}
+
+func (t *tableBuilder) endRow() {
+	t.endCell()
+	if t.row != nil {
+		t.rows = append(t.rows, t.row)
+	}
+	t.row, t.filled = nil, nil
+}
+
+func (t *tableBuilder) endCell() {
+	if t.cell == nil {
+		return
+	}
+
+	value := strings.Join(strings.FieldsFunc(t.cell.text.String(), isWhitespace), " ")
+	column := 0
+	for column < len(t.filled) && t.filled[column] {
+		column++
+	}
+
+	rowspan := t.cell.rowspan
+	if rowspan == 0 {
+		rowspan = math.MaxInt
+	}
+
+	for i := column; i < column+t.cell.colspan; i++ {
+		t.set(i, value)
+		if rowspan > 1 {
+			for len(t.spans) <= i {
+				t.spans = append(t.spans, tableSpan{})
+			}
+			t.spans[i] = tableSpan{rowspan - 1, value}
+		}
+	}
+	t.cell = nil
+}
+
+func (t *tableBuilder) set(column int, value string) {
+	for len(t.row) <= column {
+		t.row = append(t.row, "")
+		t.filled = append(t.filled, false)
+	}
+	t.row[column], t.filled[column] = value, true
+}
+
+func (t *tableBuilder) finish() [][]string {
+	width := 0
+	for _, row := range t.rows {
+		width = max(width, len(row))
+	}
+	for i := range t.rows {
+		for len(t.rows[i]) < width {
+			t.rows[i] = append(t.rows[i], "")
+		}
+	}
+	return t.rows
+}
+
+func spanAttribute(tag *StartTag, name string, minimum, maximum int) int {
+	attribute, _ := tag.GetAttribute(name)
+	value, err := strconv.Atoi(strings.TrimFunc(attribute.Value, isWhitespace))
+	if err != nil {
+		return 1
+	}
+	return min(max(value, minimum), maximum)
+}
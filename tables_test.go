@@ -0,0 +1,48 @@
+package html
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExtractTables(t *testing.T) {
+	template := `<table>
+	<thead><tr><th>Name<th COLSPAN="2">Contact</tr></thead>
+	<tbody>
+		<tr><td RowSpan="2">Ada</td><td>ada@example.com</td><td>555-01</td></tr>
+		<tr><td colspan="2">London,
+			UK</td></tr>
+		<tr><td>Bob<td>bob@example.com<td><table><tr><td>nested</td></tr></table></td></tr>
+	</tbody>
+</table><!-- end -->`
+
+	tables, err := ExtractTables(Tokenize(template))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tables) != 2 {
+		t.Fatalf("expected 2 tables, got %d", len(tables))
+	}
+
+	expected := [][]string{
+		{"Name", "Contact", "Contact"},
+		{"Ada", "ada@example.com", "555-01"},
+		{"Ada", "London, UK", "London, UK"},
+		{"Bob", "bob@example.com", ""},
+	}
+	if !reflect.DeepEqual(tables[0], expected) {
+		t.Errorf("expected %q, got %q", expected, tables[0])
+	}
+	if !reflect.DeepEqual(tables[1], [][]string{{"nested"}}) {
+		t.Errorf("unexpected nested table: %q", tables[1])
+	}
+
+	var csv strings.Builder
+	if err := WriteCSV(&csv, expected[2:]); err != nil {
+		t.Fatal(err)
+	}
+	if csv.String() != "Ada,\"London, UK\",\"London, UK\"\nBob,bob@example.com,\n" {
+		t.Errorf("unexpected CSV output: %q", csv.String())
+	}
+}
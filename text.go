@@ -0,0 +1,103 @@
+package html
+
+import (
+	"iter"
+	"slices"
+	"strings"
+)
+
+var blockElements = []string{
+	"address", "article", "aside", "blockquote", "caption", "dd", "details", "dialog", "div", "dl", "dt",
+	"fieldset", "figcaption", "figure", "footer", "form", "h1", "h2", "h3", "h4", "h5", "h6", "header",
+	"hgroup", "hr", "li", "main", "nav", "ol", "p", "pre", "section", "summary", "table", "tr", "ul",
+}
+
+var skippedTextElements = []string{"head", "script", "style", "template"}
+
+// ToText renders the token stream as readable plain text. Block elements start new lines, `<br>` breaks lines,
+// list items are bulleted, scripts and styles are skipped and whitespace outside `<pre>` is collapsed.
+// Comments are skipped and the first other Illegal token encountered is returned as the error.
+func ToText(tokens iter.Seq[Token]) (string, error) {
+	var (
+		b     strings.Builder
+		space bool
+		skip  int
+		pre   int
+	)
+
+	lineBreak := func() {
+		space = false
+		if b.Len() > 0 && !strings.HasSuffix(b.String(), "\n") {
+			b.WriteByte('\n')
+		}
+	}
+
+	for token := range tokens {
+		switch token := token.(type) {
+		case *Illegal:
+			if token.Code != CodeMarkupDeclaration {
+				return "", token
+			}
+		case *StartTag:
+			name := strings.ToLower(token.Name)
+			switch {
+			case name == "body":
+				skip = 0
+			case slices.Contains(skippedTextElements, name):
+				if !token.IsSelfClosing {
+					skip++
+				}
+			case skip > 0:
+			case name == "br":
+				b.WriteByte('\n')
+				space = false
+			case name == "li":
+				lineBreak()
+				b.WriteString("- ")
+			case slices.Contains(blockElements, name):
+				lineBreak()
+			}
+			if name == "pre" && !token.IsSelfClosing {
+				pre++
+			}
+		case *EndTag:
+			name := strings.ToLower(token.Name)
+			switch {
+			case slices.Contains(skippedTextElements, name):
+				skip = max(skip-1, 0)
+			case skip > 0:
+			case slices.Contains(blockElements, name):
+				lineBreak()
+			}
+			if name == "pre" {
+				pre = max(pre-1, 0)
+			}
+		case *Text:
+			if skip > 0 {
+				continue
+			}
+			if pre > 0 {
+				b.WriteString(token.Value)
+				space = false
+				continue
+			}
+
+			words := strings.FieldsFunc(token.Value, isWhitespace)
+			if len(words) == 0 {
+				space = space || token.Value != ""
+				continue
+			}
+
+			space = space || isWhitespace([]rune(token.Value)[0])
+			if output := b.String(); space && output != "" && !strings.HasSuffix(output, "\n") && !strings.HasSuffix(output, " ") {
+				b.WriteByte(' ')
+			}
+			b.WriteString(strings.Join(words, " "))
+
+			value := []rune(token.Value)
+			space = isWhitespace(value[len(value)-1])
+		}
+	}
+
+	return strings.TrimRight(b.String(), "\n "), nil
+}
@@ -0,0 +1,34 @@
+package html
+
+import "testing"
+
+func TestToText(t *testing.T) {
+	template := `<!DOCTYPE html><html><head><title>Ignored</title><style>p > a { color: red }</style></head>
+<body>
+	<h1>Hello,   <em>world</em>!</h1>
+	<p>First line<br>second line</p>
+	<script>if (a<b && c) { document.write("</div>") }</script>
+	<ul>
+		<li>one</li>
+		<li>two <a href="#">links</a></li>
+	</ul>
+	<pre>  keep
+    this</pre>
+</body></html><!-- end -->`
+
+	text, err := ToText(Tokenize(template))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "Hello, world!\nFirst line\nsecond line\n- one\n- two links\n  keep\n    this"
+	if text != expected {
+		t.Errorf("expected %q, got %q", expected, text)
+	}
+}
+
+func TestToTextIllegal(t *testing.T) {
+	if _, err := ToText(Tokenize(`<p>text</p><div id=unquoted>`)); err == nil {
+		t.Error("expected an error for illegal input")
+	}
+}
@@ -1,11 +1,25 @@
 package html
 
 import (
-	"errors"
+	"io"
 	"iter"
 	"regexp"
 	"slices"
+	"time"
 	"unicode"
+	"unicode/utf8"
+
+	"github.com/terawatthour/html/atom"
+)
+
+// Elements whose contents are tokenized as a single Text token, up to the matching end tag.
+// https://html.spec.whatwg.org/multipage/parsing.html#parsing-html-fragments
+var rawTextElements = []string{"iframe", "noembed", "noframes", "script", "style", "textarea", "title", "xmp"}
+
+var (
+	doctypePattern      = regexp.MustCompile(`^(?i)<!DOCTYPE\s+`)
+	doctypeNamePattern  = regexp.MustCompile(`^(?i)html`)
+	legacyCompatPattern = regexp.MustCompile(`^SYSTEM\s+("about:legacy-compat"|'about:legacy-compat')\s*>`)
 )
 
 func NewTokenizer(template string) Tokenizer {
@@ -14,43 +28,139 @@ func NewTokenizer(template string) Tokenizer {
 
 func Tokenize(template string) iter.Seq[Token] {
 	t := NewTokenizer(template)
+	return t.All()
+}
+
+// All returns an iterator over the remaining tokens, excluding the final EOF token.
+func (t *Tokenizer) All() iter.Seq[Token] {
 	return func(yield func(Token) bool) {
 		for token := t.next(); token.Kind() != "EOF" && yield(token); token = t.next() {
 		}
 	}
 }
 
+type Delimiters struct {
+	Left  string
+	Right string
+}
+
+var DefaultDelimiters = Delimiters{Left: "{{", Right: "}}"}
+
+// EnableExpressions makes the tokenizer recognize interpolation expressions enclosed in the given delimiters.
+// Expressions in text are emitted as Expression tokens, expressions in attribute values are listed
+// on the Attribute, the value itself being left intact. Expressions in place of an attribute are listed
+// on the StartTag, and an unquoted expression may be used as an attribute value.
+func (t *Tokenizer) EnableExpressions(delimiters Delimiters) {
+	t.left, t.right = []rune(delimiters.Left), []rune(delimiters.Right)
+	t.passthrough = false
+}
+
+// EnableLazyAttributes makes the tokenizer skip over attributes of start tags, only recording their span.
+// Attributes of such tags are nil until StartTag.ParseAttributes is called, which saves building attribute maps
+// for consumers interested only in tag names.
+func (t *Tokenizer) EnableLazyAttributes() {
+	t.lazyAttributes = true
+}
+
+// EnablePassthrough is like EnableExpressions, except that expressions in text are kept verbatim inside
+// the surrounding Text token instead of being emitted separately. Markup-like characters inside an expression
+// never end the text, which allows Go template files (`{{ ... }}` actions) to be tokenized without corrupting actions.
+func (t *Tokenizer) EnablePassthrough(delimiters Delimiters) {
+	t.EnableExpressions(delimiters)
+	t.passthrough = true
+}
+
+// EnableScripting mirrors the scripting flag of the HTML parser, with which the contents of `<noscript>` are raw
+// text rather than markup, as in browsers running scripts.
+func (t *Tokenizer) EnableScripting() {
+	t.scripting = true
+}
+
+// SetFilename names the file the template comes from, for the diagnostics reported by the tokenizer.
+func (t *Tokenizer) SetFilename(filename string) {
+	t.filename = filename
+}
+
 type Tokenizer struct {
-	template []rune
-	i        int
-	line     int
-	column   int
+	template       []rune
+	i              int
+	line           int
+	column         int
+	rawText        []rune
+	left           []rune
+	right          []rune
+	passthrough    bool
+	lazyAttributes bool
+	scripting      bool
+	reader         runeReader
+	filename       string
+	trace          func(TraceEvent)
+	metrics        *tokenizerMetrics
+	// reachedEnd is set whenever the tokenizer looks at the end of its input, telling streams which tokens could
+	// change with more input.
+	reachedEnd bool
+	// rawTextScanned is where the last scan of raw text could resume with more input, as no end tag starts before it.
+	// Streams keep it across writes so that long raw text is not scanned again from its start.
+	rawTextScanned Location
 }
 
 func (t *Tokenizer) next() Token {
-	if t.match(regexp.MustCompile(`^(?i)<!DOCTYPE\s+`)) {
-		return t.doctype()
+	var token RawToken
+	t.NextInto(&token)
+	return t.token(&token)
+}
+
+// NextInto reads the next token into the given RawToken, reusing its buffers. Once the buffers have grown large
+// enough, tokenization does not allocate unless the input is illegal. At the end of input the token's Kind is EOF.
+func (t *Tokenizer) NextInto(token *RawToken) {
+	token.reset()
+	if t.trace != nil {
+		defer func(start Location, rawText []rune) { t.traceToken(t.token(token), start, rawText) }(t.location(), t.rawText)
+	}
+	if t.metrics != nil {
+		defer t.record(token, t.i, time.Now())
+	}
+
+	if len(t.rawText) > 0 {
+		if t.rawTextContent(token); len(token.Data) > 0 {
+			return
+		}
+	}
+
+	if t.is('<') && t.peek() == '!' && t.match(doctypePattern) {
+		t.doctype(token)
+	} else if t.is('<') && t.peek() == '!' {
+		t.markupDeclaration(token)
 	} else if t.is('<') && t.peek() == '/' {
-		return t.endTag()
+		t.endTag(token)
 	} else if t.is('<') && isLetter(t.peek()) {
-		return t.startTag()
+		t.startTag(token)
 	} else if t.is(0) {
-		return &Eof{t.location()}
+		token.Kind, token.Location = "EOF", t.location()
+	} else if t.isExpressionStart() && !t.passthrough {
+		t.expression(token)
+	} else {
+		t.text(token)
 	}
+}
 
-	textLocation := t.location()
+func (t *Tokenizer) text(token *RawToken) {
+	location := t.location()
 	for !t.is(0) && (!t.is('<') || (t.is('<') && !isLetter(t.peek()) && t.peek() != '/' && t.peek() != '!')) {
-		t.advance()
+		if !t.isExpressionStart() {
+			t.advance()
+		} else if !t.passthrough {
+			break
+		} else if !t.expression(token) {
+			return
+		}
 	}
 
-	return &Text{
-		string(t.template[textLocation.Cursor:t.i]),
-		textLocation,
-	}
+	token.Kind, token.Data, token.Location = "TEXT", t.template[location.Cursor:t.i], location
 }
 
 // https://html.spec.whatwg.org/multipage/syntax.html#the-doctype
-func (t *Tokenizer) doctype() Token {
+func (t *Tokenizer) doctype(token *RawToken) {
 	location := t.location()
 
 	for range len("<!DOCTYPE ") {
@@ -58,8 +168,9 @@ func (t *Tokenizer) doctype() Token {
 	}
 
 	t.skipWhitespace()
-	if !t.match(regexp.MustCompile(`^(?i)html`)) {
-		return &Illegal{"expected `html` after `<!DOCTYPE `", t.location()}
+	if !t.match(doctypeNamePattern) {
+		token.illegal(CodeInvalidDoctype, "expected `html` after `<!DOCTYPE `", t.location())
+		return
 	}
 
 	for range len("html") {
@@ -67,152 +178,377 @@ func (t *Tokenizer) doctype() Token {
 	}
 
 	t.skipWhitespace()
-	if t.match(regexp.MustCompile(`^SYSTEM\s+("about:legacy-compat"|'about:legacy-compat')\s*>`)) {
+	if t.match(legacyCompatPattern) {
 		t.until('>')
 		t.advance()
-		return &Doctype{true, location}
+		token.Kind, token.HasSystem, token.Location = "DOCTYPE", true, location
+		return
 	}
 
 	if !t.consume('>') {
-		return &Illegal{"malformed DOCTYPE, expected closing angle bracket", t.location()}
+		token.illegal(CodeMalformedDoctype, "malformed DOCTYPE, expected closing angle bracket", t.location())
+		return
 	}
 
-	return &Doctype{Location: location}
+	token.Kind, token.Location = "DOCTYPE", location
 }
 
-func (t *Tokenizer) startTag() Token {
-	var err error
+// NOTE: comments and other markup declarations are not supported yet, they are skipped and reported as illegal
+func (t *Tokenizer) markupDeclaration(token *RawToken) {
+	location := t.location()
+
+	closing := []rune(">")
+	if t.startsWith([]rune("<!--")) {
+		closing = []rune("-->")
+		t.advance()
+		t.advance()
+	}
+	t.advance()
+	t.advance()
+
+	for !t.is(0) && !t.startsWith(closing) {
+		t.advance()
+	}
+	for range closing {
+		t.advance()
+	}
+
+	token.illegal(CodeMarkupDeclaration, "comments and markup declarations are not supported", location)
+}
+
+func (t *Tokenizer) startTag(token *RawToken) {
+	var err *Illegal
 
 	location := t.location()
 	t.advance()
 
 	if !isLetter(t.current()) {
-		return &Illegal{Reason: "expected tag name", Location: t.location()}
+		token.illegal(CodeMissingTagName, "expected tag name", t.location())
+		return
+	}
+
+	if token.Name, err = t.tagName(); err != nil {
+		token.illegal(err.Code, err.Reason, t.location())
+		return
 	}
 
-	tag := StartTag{
-		Location:   location,
-		Attributes: make(map[string]Attribute),
+	t.skipWhitespace()
+
+	if t.lazyAttributes {
+		token.lazy, token.attributesStart = true, t.location()
+		if !t.skipAttributes(token) {
+			return
+		}
+		token.attributesEnd = t.i
+	} else if !t.attributes(token) {
+		return
+	}
+
+	token.IsSelfClosing = t.consume('/')
+
+	if !t.consume('>') {
+		token.illegal(CodeMissingClosingBracket, "expected closing angle bracket", t.location())
+		return
 	}
 
-	if tag.Name, err = t.tagName(); err != nil {
-		return &Illegal{Reason: err.Error(), Location: t.location()}
+	if !token.IsSelfClosing && (isRawTextElement(token.Name) || t.scripting && isElement(token.Name, "noscript")) {
+		t.rawText = token.Name
 	}
 
-	t.skipWhitespace()
+	token.Kind, token.Atom, token.Location = "START_TAG", lookupAtom(token.Name), location
+}
+
+func (t *Tokenizer) attributes(token *RawToken) bool {
+	var err *Illegal
 
 	for !t.is('>', '/') {
-		attribute := Attribute{
+		if t.isExpressionStart() {
+			if !t.attributeExpression(token, -1) {
+				return false
+			}
+			t.skipWhitespace()
+			continue
+		}
+
+		attribute := RawAttribute{
 			NameLocation: t.location(),
 		}
 
 		if attribute.Name, err = t.attributeName(); err != nil {
-			return &Illegal{Reason: err.Error(), Location: t.location()}
+			token.illegal(err.Code, err.Reason, t.location())
+			return false
 		}
+		attribute.Atom, attribute.NameEnd = lookupAtom(attribute.Name), t.location()
 
 		t.skipWhitespace()
-		if t.consume('=') {
+		if t.is('=') {
+			attribute.EqualsLocation = t.location()
+			t.advance()
 			t.skipWhitespace()
 			attribute.ValueLocation = t.location()
 
+			if t.isExpressionStart() {
+				if !t.attributeExpression(token, len(token.Attributes)) {
+					return false
+				}
+				attribute.Value, attribute.ValueEnd = t.template[attribute.ValueLocation.Cursor:t.i], t.location()
+				token.Attributes = append(token.Attributes, attribute)
+				t.skipWhitespace()
+				continue
+			}
+
 			// NOTE: contrary to 13.1.2.3, unquoted attribute values are disallowed
 			if !t.is('"', '\'') {
-				return &Illegal{Reason: "expected quotes in attribute definition", Location: t.location()}
+				token.illegal(CodeUnquotedAttributeValue, "expected quotes in attribute definition", t.location())
+				return false
 			}
 
-			if attribute.Value, err = t.string(); err != nil {
-				return &Illegal{Reason: err.Error(), Location: t.location()}
+			var ok bool
+			attribute.Quote = t.current()
+			if attribute.Value, ok = t.string(token, len(token.Attributes)); !ok {
+				return false
 			}
+			attribute.ValueEnd = t.location()
 		}
 
-		tag.Attributes[attribute.Name] = attribute
+		token.Attributes = append(token.Attributes, attribute)
 
 		t.skipWhitespace()
 	}
 
-	tag.IsSelfClosing = t.consume('/')
+	return true
+}
 
-	if !t.consume('>') {
-		return &Illegal{Reason: "expected closing angle bracket", Location: t.location()}
+// skipAttributes moves past the attributes of a tag, only validating that quotes and expressions are terminated.
+func (t *Tokenizer) skipAttributes(token *RawToken) bool {
+	for !t.is('>') && (!t.is('/') || t.peek() != '>') {
+		switch {
+		case t.is(0):
+			token.illegal(CodeEOFInTag, "unexpected end of input", t.location())
+			return false
+		case t.isExpressionStart():
+			if !t.expression(token) {
+				return false
+			}
+		case t.is('"', '\''):
+			quote := t.advance()
+			t.until(quote, '\\')
+			if !t.consume(quote) {
+				token.illegal(CodeUnterminatedAttributeValue, "expected closing quote", t.location())
+				return false
+			}
+		default:
+			t.advance()
+		}
 	}
+	return true
+}
 
-	return &tag
+// https://html.spec.whatwg.org/multipage/parsing.html#rawtext-state
+func (t *Tokenizer) rawTextContent(token *RawToken) {
+	name := t.rawText
+	t.rawText = nil
+
+	location := t.location()
+	if resume := t.rawTextScanned; resume.Cursor > t.i && resume.Cursor <= len(t.template) {
+		t.i, t.line, t.column = resume.Cursor, resume.Line, resume.Column
+	}
+
+	// Expressions are stepped over, so that an end tag within one does not end the raw text. Only input too close to
+	// the end for the end tag or the left delimiter to fit after it may read differently with more input.
+	tail := len(t.template) - max(2+len(name), len(t.left))
+	t.rawTextScanned = Location{}
+	for !t.is(0) && !t.isEndTagOf(name) {
+		if t.i >= tail && t.rawTextScanned == (Location{}) {
+			t.rawTextScanned = t.location()
+		}
+		if !t.isExpressionStart() {
+			t.advance()
+		} else if !t.expression(token) {
+			return
+		}
+	}
+	if t.rawTextScanned == (Location{}) {
+		t.rawTextScanned = t.location()
+	}
+
+	token.Kind, token.Data, token.Location = "TEXT", t.template[location.Cursor:t.i], location
+}
+
+func (t *Tokenizer) isEndTagOf(name []rune) bool {
+	if !t.is('<') || t.peek() != '/' {
+		return false
+	}
+	if t.i+2+len(name) > len(t.template) {
+		t.reachedEnd = true
+		return false
+	}
+
+	if !equalFold(t.template[t.i+2:t.i+2+len(name)], name) {
+		return false
+	}
+
+	next := rune(0)
+	if t.i+2+len(name) < len(t.template) {
+		next = t.template[t.i+2+len(name)]
+	} else {
+		t.reachedEnd = true
+	}
+	return next == 0 || next == '/' || next == '>' || isWhitespace(next)
 }
 
-func (t *Tokenizer) endTag() Token {
-	var err error
-	tag := EndTag{Location: t.location()}
+func (t *Tokenizer) endTag(token *RawToken) {
+	var err *Illegal
+	location := t.location()
 	t.advance()
 	t.advance()
 
 	if !isLetter(t.current()) {
-		return &Illegal{Reason: "expected tag name", Location: t.location()}
+		token.illegal(CodeMissingTagName, "expected tag name", t.location())
+		return
 	}
 
-	if tag.Name, err = t.tagName(); err != nil {
-		return &Illegal{Reason: err.Error(), Location: t.location()}
+	if token.Name, err = t.tagName(); err != nil {
+		token.illegal(err.Code, err.Reason, t.location())
+		return
 	}
 
 	t.skipWhitespace()
 
 	if !t.consume('>') {
-		return &Illegal{Reason: "expected closing angle bracket", Location: t.location()}
+		token.illegal(CodeMissingClosingBracket, "expected closing angle bracket", t.location())
+		return
 	}
 
-	return &tag
+	token.Kind, token.Atom, token.Location = "END_TAG", lookupAtom(token.Name), location
 }
 
-func (t *Tokenizer) tagName() (string, error) {
+func (t *Tokenizer) tagName() ([]rune, *Illegal) {
 	validate := func(c rune) bool {
-		return isLetter(c) || c == '-' || c == ':'
+		return isLetter(c) || isDigit(c) || c == '-' || c == ':'
 	}
 
 	start := t.i
 
 	if !isLetter(t.advance()) {
-		return "", errors.New("tag name must start with a letter")
+		return nil, &Illegal{Code: CodeInvalidTagName, Reason: "tag name must start with a letter"}
 	}
 
-	for c := t.current(); !isWhitespace(c) && c != 0 && c != '>'; c = t.current() {
+	for c := t.current(); !isWhitespace(c) && c != 0 && c != '>' && c != '/'; c = t.current() {
 		if !validate(c) {
-			return "", errors.New("unexpected character in tag name")
+			return nil, &Illegal{Code: CodeInvalidTagName, Reason: "unexpected character in tag name"}
 		}
 		t.advance()
 	}
-	return string(t.template[start:t.i]), nil
+	return t.template[start:t.i], nil
 }
 
-func (t *Tokenizer) attributeName() (string, error) {
+func (t *Tokenizer) attributeName() ([]rune, *Illegal) {
 	validate := func(c rune) bool {
 		return isDigit(c) || isLetter(c) || c == '-' || c == '_' || c == ':'
 	}
 
 	if !validate(t.current()) {
-		return "", errors.New("attribute name must not start with a digit")
+		return nil, &Illegal{Code: CodeInvalidAttributeName, Reason: "attribute name must not start with a digit"}
 	}
 
 	start := t.i
 	for c := t.current(); !isWhitespace(c) && c != 0 && c != '>' && c != '='; c = t.current() {
 		if !validate(c) {
-			return "", errors.New("unexpected character in attribute name")
+			return nil, &Illegal{Code: CodeInvalidAttributeName, Reason: "unexpected character in attribute name"}
 		}
 		t.advance()
 	}
 
 	if t.is(0) {
-		return "", errors.New("unexpected end of input")
+		return nil, &Illegal{Code: CodeEOFInTag, Reason: "unexpected end of input"}
+	}
+
+	return t.template[start:t.i], nil
+}
+
+// string reads a quoted attribute value. Expressions found in the value are recorded on the token as belonging
+// to the attribute with the given index.
+func (t *Tokenizer) string(token *RawToken, attribute int) ([]rune, bool) {
+	var previous rune
+
+	quote := t.advance()
+	start := t.i
+	for c := t.current(); c != 0 && (c != quote || previous == '\\'); c = t.current() {
+		if !t.isExpressionStart() {
+			previous = t.advance()
+			continue
+		}
+
+		if !t.attributeExpression(token, attribute) {
+			return nil, false
+		}
+		previous = 0
+	}
+
+	literal := t.template[start:t.i]
+	if !t.consume(quote) {
+		token.illegal(CodeUnterminatedAttributeValue, "expected closing quote", t.location())
+		return nil, false
+	}
+	return literal, true
+}
+
+// attributeExpression reads an expression inside a tag, recording it as belonging to the attribute with the given
+// index, or to the tag itself for a negative index.
+func (t *Tokenizer) attributeExpression(token *RawToken, attribute int) bool {
+	var expression RawToken
+	if !t.expression(&expression) {
+		token.illegal(expression.Code, expression.Reason, expression.Location)
+		return false
+	}
+
+	token.Expressions = append(token.Expressions, RawExpression{
+		Value:     expression.Data,
+		Attribute: attribute,
+		Location:  expression.Location,
+	})
+	return true
+}
+
+func (t *Tokenizer) expression(token *RawToken) bool {
+	location := t.location()
+	for range t.left {
+		t.advance()
+	}
+
+	start := t.i
+	for !t.startsWith(t.right) {
+		switch c := t.advance(); c {
+		case 0:
+			token.illegal(CodeUnterminatedExpression, "unterminated expression", location)
+			return false
+		case '"', '\'', '`':
+			t.until(c, '\\')
+			if t.advance() == 0 {
+				token.illegal(CodeUnterminatedExpressionString, "unterminated string in expression", location)
+				return false
+			}
+		}
+	}
+
+	token.Kind, token.Data, token.Location = "EXPRESSION", t.template[start:t.i], location
+	for range t.right {
+		t.advance()
 	}
+	return true
+}
 
-	return string(t.template[start:t.i]), nil
+func (t *Tokenizer) isExpressionStart() bool {
+	return len(t.left) > 0 && t.startsWith(t.left)
 }
 
-func (t *Tokenizer) string() (string, error) {
-	literal := t.until(t.advance(), '\\')
-	c := t.advance()
-	if c != '"' && c != '\'' {
-		return "", errors.New("expected closing quote")
+func (t *Tokenizer) startsWith(prefix []rune) bool {
+	if t.i+len(prefix) > len(t.template) {
+		t.reachedEnd = true
+		return false
 	}
-	return literal, nil
+	return slices.Equal(t.template[t.i:t.i+len(prefix)], prefix)
 }
 
 func (t *Tokenizer) skipWhitespace() {
@@ -221,8 +557,7 @@ func (t *Tokenizer) skipWhitespace() {
 	}
 }
 
-func (t *Tokenizer) until(what rune, notAfter ...rune) string {
-	start := t.i
+func (t *Tokenizer) until(what rune, notAfter ...rune) {
 	var previous rune
 
 	for c := t.current(); c != 0; previous, c = t.advance(), t.current() {
@@ -233,11 +568,29 @@ func (t *Tokenizer) until(what rune, notAfter ...rune) string {
 			break
 		}
 	}
-	return string(t.template[start:t.i])
 }
 
+// match reports whether the pattern matches at the current position, reading only as much input as the pattern needs.
 func (t *Tokenizer) match(pattern *regexp.Regexp) bool {
-	return pattern.MatchString(string(t.template[t.i:]))
+	t.reader = runeReader{runes: t.template[t.i:]}
+	matched := pattern.MatchReader(&t.reader)
+	if t.reader.i >= len(t.reader.runes) {
+		t.reachedEnd = true
+	}
+	return matched
+}
+
+type runeReader struct {
+	runes []rune
+	i     int
+}
+
+func (r *runeReader) ReadRune() (rune, int, error) {
+	if r.i >= len(r.runes) {
+		return 0, 0, io.EOF
+	}
+	r.i++
+	return r.runes[r.i-1], utf8.RuneLen(r.runes[r.i-1]), nil
 }
 
 func (t *Tokenizer) is(what ...rune) bool {
@@ -254,6 +607,7 @@ func (t *Tokenizer) consume(what rune) bool {
 
 func (t *Tokenizer) current() rune {
 	if t.i >= len(t.template) {
+		t.reachedEnd = true
 		return 0
 	}
 	return t.template[t.i]
@@ -261,6 +615,7 @@ func (t *Tokenizer) current() rune {
 
 func (t *Tokenizer) peek() rune {
 	if t.i+1 >= len(t.template) {
+		t.reachedEnd = true
 		return 0
 	}
 	return t.template[t.i+1]
@@ -284,6 +639,83 @@ func (t *Tokenizer) location() Location {
 	return Location{Line: t.line, Column: t.column, Cursor: t.i}
 }
 
+// intern returns the atom of a case-insensitive name, reusing the atom's string as the name when it is lowercase.
+func intern(name []rune) (atom.Atom, string) {
+	a, lowercase := atomOf(name)
+	if a != 0 && lowercase {
+		return a, a.String()
+	}
+	return a, string(name)
+}
+
+// lookupAtom returns the atom of a case-insensitive name without allocating.
+func lookupAtom(name []rune) atom.Atom {
+	a, _ := atomOf(name)
+	return a
+}
+
+func atomOf(name []rune) (atom.Atom, bool) {
+	var buffer [24]byte
+	if len(name) > len(buffer) {
+		return 0, false
+	}
+
+	lowercase := true
+	for i, c := range name {
+		if c >= utf8.RuneSelf {
+			return 0, false
+		}
+		if 'A' <= c && c <= 'Z' {
+			c += 'a' - 'A'
+			lowercase = false
+		}
+		buffer[i] = byte(c)
+	}
+	return atom.Lookup(buffer[:len(name)]), lowercase
+}
+
+func isRawTextElement(name []rune) bool {
+	for _, element := range rawTextElements {
+		if isElement(name, element) {
+			return true
+		}
+	}
+	return false
+}
+
+// isElement reports whether name is the lowercase ASCII element name, ignoring case.
+func isElement(name []rune, element string) bool {
+	if len(element) != len(name) || slices.ContainsFunc(name, func(c rune) bool { return c >= utf8.RuneSelf }) {
+		return false
+	}
+	for i := range name {
+		if toLower(name[i]) != rune(element[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// equalFold reports whether a and b are equal under ASCII case folding.
+func equalFold(a, b []rune) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if toLower(a[i]) != toLower(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func toLower(c rune) rune {
+	if 'A' <= c && c <= 'Z' {
+		return c + 'a' - 'A'
+	}
+	return c
+}
+
 func isDigit(r rune) bool {
 	return unicode.IsDigit(r) && r < 128
 }
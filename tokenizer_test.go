@@ -2,7 +2,10 @@ package html
 
 import (
 	"fmt"
+	"slices"
 	"testing"
+
+	"github.com/terawatthour/html/atom"
 )
 
 func TestTokenize(t *testing.T) {
@@ -22,3 +25,335 @@ func TestTokenize(t *testing.T) {
 		}
 	}
 }
+
+func TestTokenizeRawText(t *testing.T) {
+	template := `<script>if (a<b) { x = "</div>" }</SCRIPT ><h2>title</h2>`
+
+	var tokens []Token
+	for token := range Tokenize(template) {
+		tokens = append(tokens, token)
+	}
+
+	if len(tokens) != 6 {
+		t.Fatalf("expected 6 tokens, got %d", len(tokens))
+	}
+	if text, ok := tokens[1].(*Text); !ok || text.Value != `if (a<b) { x = "</div>" }` {
+		t.Errorf("unexpected script contents: %#v", tokens[1])
+	}
+	if tag, ok := tokens[3].(*StartTag); !ok || tag.Name != "h2" {
+		t.Errorf("expected h2 start tag, got %#v", tokens[3])
+	}
+}
+
+func TestTokenizeExpressions(t *testing.T) {
+	template := `<a href="/users/{{ user.id }}" title='{{ "}}" }}'>{{ user.name }} < {{ 2 }}</a><p>[[ custom ]]</p>`
+
+	tokenizer := NewTokenizer(template)
+	tokenizer.EnableExpressions(DefaultDelimiters)
+
+	var tokens []Token
+	for token := range tokenizer.All() {
+		if illegal, ok := token.(*Illegal); ok {
+			t.Fatal(illegal)
+		}
+		tokens = append(tokens, token)
+	}
+
+	tag := tokens[0].(*StartTag)
+	if href := tag.Attributes["href"]; href.Value != "/users/{{ user.id }}" || len(href.Expressions) != 1 || href.Expressions[0].Value != " user.id " || href.Expressions[0].Column != 17 {
+		t.Errorf("unexpected href attribute: %+v", href)
+	}
+	if title := tag.Attributes["title"]; len(title.Expressions) != 1 || title.Expressions[0].Value != ` "}}" ` {
+		t.Errorf("unexpected title attribute: %+v", title)
+	}
+
+	kinds := make([]string, len(tokens))
+	for i, token := range tokens {
+		kinds[i] = token.Kind()
+	}
+	expected := []string{"START_TAG", "EXPRESSION", "TEXT", "EXPRESSION", "END_TAG", "START_TAG", "TEXT", "END_TAG"}
+	if !slices.Equal(kinds, expected) {
+		t.Fatalf("expected %v, got %v", expected, kinds)
+	}
+	if text := tokens[2].(*Text); text.Value != " < " {
+		t.Errorf("unexpected text between expressions: %q", text.Value)
+	}
+
+	tokenizer = NewTokenizer(`<p>[[ custom ]]</p>`)
+	tokenizer.EnableExpressions(Delimiters{Left: "[[", Right: "]]"})
+	for token := range tokenizer.All() {
+		if expression, ok := token.(*Expression); ok && expression.Value != " custom " {
+			t.Errorf("unexpected expression value: %q", expression.Value)
+		}
+	}
+}
+
+func TestTokenizePassthrough(t *testing.T) {
+	template := `<ul>{{ range .Items }}<li {{ if .Active }}class="active"{{ end }} data-id={{ .ID }}>{{ if lt .A .B }}{{ "<b>" }}{{ end }}</li>{{ end }}</ul>`
+
+	tokenizer := NewTokenizer(template)
+	tokenizer.EnablePassthrough(DefaultDelimiters)
+
+	var tokens []Token
+	for token := range tokenizer.All() {
+		if illegal, ok := token.(*Illegal); ok {
+			t.Fatal(illegal)
+		}
+		tokens = append(tokens, token)
+	}
+
+	if len(tokens) != 7 {
+		t.Fatalf("expected 7 tokens, got %d", len(tokens))
+	}
+	if text := tokens[1].(*Text); text.Value != "{{ range .Items }}" {
+		t.Errorf("unexpected text: %q", text.Value)
+	}
+
+	li := tokens[2].(*StartTag)
+	if len(li.Expressions) != 2 || li.Expressions[0].Value != " if .Active " || li.Expressions[1].Value != " end " {
+		t.Errorf("unexpected tag expressions: %+v", li.Expressions)
+	}
+	if id := li.Attributes["data-id"]; id.Value != "{{ .ID }}" || len(id.Expressions) != 1 {
+		t.Errorf("unexpected unquoted expression value: %+v", id)
+	}
+	if text := tokens[3].(*Text); text.Value != `{{ if lt .A .B }}{{ "<b>" }}{{ end }}` {
+		t.Errorf("actions must stay inside a single text token, got %q", text.Value)
+	}
+}
+
+func TestTokenizeRawTextExpressions(t *testing.T) {
+	template := `<script>var a = {{ "</script>" }};</script><p>`
+	for _, enable := range []func(*Tokenizer, Delimiters){(*Tokenizer).EnableExpressions, (*Tokenizer).EnablePassthrough} {
+		tokenizer := NewTokenizer(template)
+		enable(&tokenizer, DefaultDelimiters)
+
+		var kinds []string
+		var texts []string
+		for token := range tokenizer.All() {
+			kinds = append(kinds, token.Kind())
+			if text, ok := token.(*Text); ok {
+				texts = append(texts, text.Value)
+			}
+		}
+		if expected := []string{"START_TAG", "TEXT", "END_TAG", "START_TAG"}; !slices.Equal(kinds, expected) || !slices.Equal(texts, []string{`var a = {{ "</script>" }};`}) {
+			t.Errorf("expected the end tag within the expression to be part of the script, got %v %q", kinds, texts)
+		}
+	}
+}
+
+func TestTokenizeMarkupDeclaration(t *testing.T) {
+	var kinds []string
+	for token := range Tokenize(`a<!-- <p> -->b<br/><![CDATA[x]]>`) {
+		kinds = append(kinds, token.Kind())
+	}
+
+	expected := []string{"TEXT", "ILLEGAL", "TEXT", "START_TAG", "ILLEGAL"}
+	if !slices.Equal(kinds, expected) {
+		t.Errorf("expected %v, got %v", expected, kinds)
+	}
+}
+
+func TestTokenizeDuplicateAttributes(t *testing.T) {
+	for token := range Tokenize(`<p class="a" CLASS="b" class="c">`) {
+		if tag := token.(*StartTag); len(tag.Attributes) != 1 || tag.Attributes["class"].Value != "a" {
+			t.Errorf("expected only the first of the duplicate attributes to be kept, got %+v", tag.Attributes)
+		}
+		break
+	}
+}
+
+func TestTokenizeLazyAttributes(t *testing.T) {
+	template := "<div\n  id=\"main\" title='a > b'/><p class=\"x>"
+
+	tokenizer := NewTokenizer(template)
+	tokenizer.EnableLazyAttributes()
+
+	var tokens []Token
+	for token := range tokenizer.All() {
+		tokens = append(tokens, token)
+	}
+	if len(tokens) != 2 {
+		t.Fatalf("expected 2 tokens, got %d", len(tokens))
+	}
+
+	div := tokens[0].(*StartTag)
+	if div.Attributes != nil || !div.IsSelfClosing || div.RawAttributes() != `id="main" title='a > b'` {
+		t.Fatalf("unexpected lazy tag: %+v", div)
+	}
+
+	if err := div.ParseAttributes(); err != nil {
+		t.Fatal(err)
+	}
+	if title := div.Attributes["title"]; title.Value != "a > b" || title.NameLocation != (Location{Line: 2, Column: 13, Cursor: 17}) {
+		t.Errorf("unexpected title attribute: %+v", title)
+	}
+	if div.RawAttributes() != "" || div.ParseAttributes() != nil {
+		t.Error("attributes must be parsed only once")
+	}
+
+	if _, ok := tokens[1].(*Illegal); !ok {
+		t.Errorf("expected unterminated quote to be illegal, got %#v", tokens[1])
+	}
+}
+
+func TestTokenizeAtoms(t *testing.T) {
+	var tokens []Token
+	for token := range Tokenize(`<DIV Class="a" x-data="b"></div><my-element>`) {
+		tokens = append(tokens, token)
+	}
+
+	div := tokens[0].(*StartTag)
+	if div.Atom != atom.Div || div.Name != "DIV" {
+		t.Errorf("unexpected start tag atom: %v %q", div.Atom, div.Name)
+	}
+	if div.Attributes["Class"].Atom != atom.Class || div.Attributes["x-data"].Atom != 0 {
+		t.Errorf("unexpected attribute atoms: %+v", div.Attributes)
+	}
+	if end := tokens[1].(*EndTag); end.Atom != atom.Div {
+		t.Errorf("unexpected end tag atom: %v", end.Atom)
+	}
+	if custom := tokens[2].(*StartTag); custom.Atom != 0 || custom.Name != "my-element" {
+		t.Errorf("unexpected custom element: %+v", custom)
+	}
+}
+
+const benchmarkTemplate = `<!DOCTYPE html>
+<ul class="items" data-count="3">
+	<li id="first"><a href="/one" title='One'>One</a></li>
+	<li><SCRIPT>if (a < b) { go() }</SCRIPT></li>
+	<li>Three <br/> lines</li>
+</ul>`
+
+func TestNextIntoAllocations(t *testing.T) {
+	if raceEnabled {
+		t.Skip("the race detector allocates")
+	}
+	var token RawToken
+	tokenizer := NewTokenizer(benchmarkTemplate)
+	template := tokenizer.template
+	for tokenizer.NextInto(&token); token.Kind != "EOF"; tokenizer.NextInto(&token) {
+		if token.Kind == "ILLEGAL" {
+			t.Fatal(token.Reason)
+		}
+	}
+
+	allocations := testing.AllocsPerRun(100, func() {
+		tokenizer = Tokenizer{template: template, line: 1, column: 1}
+		for tokenizer.NextInto(&token); token.Kind != "EOF"; tokenizer.NextInto(&token) {
+		}
+	})
+	if allocations != 0 {
+		t.Errorf("expected steady state tokenization not to allocate, got %v allocations", allocations)
+	}
+}
+
+func BenchmarkNextInto(b *testing.B) {
+	b.ReportAllocs()
+	var token RawToken
+	var tokenizer Tokenizer
+	template := []rune(benchmarkTemplate)
+	for range b.N {
+		tokenizer = Tokenizer{template: template, line: 1, column: 1}
+		for tokenizer.NextInto(&token); token.Kind != "EOF"; tokenizer.NextInto(&token) {
+		}
+	}
+}
+
+func BenchmarkTokenize(b *testing.B) {
+	b.ReportAllocs()
+	for range b.N {
+		for range Tokenize(benchmarkTemplate) {
+		}
+	}
+}
+
+func TestAttributeSpans(t *testing.T) {
+	tokenizer := NewTokenizer("<a href = 'x\ny' hidden id={{ id }}>")
+	tokenizer.EnableExpressions(DefaultDelimiters)
+
+	var tag *StartTag
+	for token := range tokenizer.All() {
+		tag = token.(*StartTag)
+		break
+	}
+
+	href := tag.Attributes["href"]
+	if href.NameEnd != (Location{1, 8, 7}) || href.EqualsLocation != (Location{1, 9, 8}) || href.Quote != '\'' {
+		t.Errorf("unexpected href spans: %+v", href)
+	}
+	if href.ValueLocation != (Location{1, 11, 10}) || href.ValueEnd != (Location{2, 3, 15}) {
+		t.Errorf("unexpected href value span: %+v", href)
+	}
+	if value := href.ValueRange(); value != (Range{Location{1, 12, 11}, Location{2, 2, 14}}) {
+		t.Errorf("unexpected href value range: %+v", value)
+	}
+
+	hidden := tag.Attributes["hidden"]
+	if hidden.NameEnd != (Location{2, 10, 22}) || hidden.EqualsLocation != (Location{}) || hidden.ValueEnd != (Location{}) {
+		t.Errorf("unexpected hidden spans: %+v", hidden)
+	}
+
+	id := tag.Attributes["id"]
+	if id.Quote != 0 || id.ValueLocation != (Location{2, 14, 26}) || id.ValueEnd != (Location{2, 22, 34}) {
+		t.Errorf("unexpected id spans: %+v", id)
+	}
+}
+
+func TestStartTagAccessors(t *testing.T) {
+	var tag *StartTag
+	for token := range Tokenize("<div ID=\"main\" Class=\" card\n\tlarge  \" hidden>") {
+		tag = token.(*StartTag)
+		break
+	}
+
+	if attribute, ok := tag.GetAttribute("class"); !ok || attribute.Name != "Class" {
+		t.Errorf("expected a case-insensitive lookup, got %+v", attribute)
+	}
+	if !tag.HasAttribute("hidden") || tag.HasAttribute("title") {
+		t.Error("unexpected HasAttribute result")
+	}
+	if tag.ID() != "main" {
+		t.Errorf("unexpected id %q", tag.ID())
+	}
+	if classes := tag.Classes(); !slices.Equal(classes, []string{"card", "large"}) {
+		t.Errorf("unexpected classes %q", classes)
+	}
+}
+
+func TestScripting(t *testing.T) {
+	template := `<noscript><img src="pixel.gif"></noscript>`
+
+	var kinds []string
+	for token := range Tokenize(template) {
+		kinds = append(kinds, token.Kind())
+	}
+	if expected := []string{"START_TAG", "START_TAG", "END_TAG"}; !slices.Equal(kinds, expected) {
+		t.Errorf("without scripting: expected %v, got %v", expected, kinds)
+	}
+
+	tokenizer := NewTokenizer(template)
+	tokenizer.EnableScripting()
+	kinds = nil
+	for token := range tokenizer.All() {
+		kinds = append(kinds, token.Kind())
+		if text, ok := token.(*Text); ok && text.Value != `<img src="pixel.gif">` {
+			t.Errorf("unexpected raw text %q", text.Value)
+		}
+	}
+	if expected := []string{"START_TAG", "TEXT", "END_TAG"}; !slices.Equal(kinds, expected) {
+		t.Errorf("with scripting: expected %v, got %v", expected, kinds)
+	}
+}
+
+func TestClassList(t *testing.T) {
+	classes := ParseClassList(" b  a\tb\nc a ")
+	if !slices.Equal(classes, []string{"b", "a", "c"}) {
+		t.Errorf("unexpected classes %q", classes)
+	}
+	if joined := JoinClassList(classes); joined != "b a c" {
+		t.Errorf("unexpected serialization %q", joined)
+	}
+	if classes := ParseClassList("  "); classes != nil {
+		t.Errorf("expected no classes, got %q", classes)
+	}
+}
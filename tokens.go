@@ -1,17 +1,24 @@
 package html
 
+import (
+	"slices"
+	"strings"
+
+	"github.com/terawatthour/html/atom"
+)
+
 type Token interface {
 	Kind() string
 }
 
 type Location struct {
-	Line   int
-	Column int
-	Cursor int
+	Line   int `json:"line"`
+	Column int `json:"column"`
+	Cursor int `json:"cursor"`
 }
 
 type Doctype struct {
-	HasSystem bool
+	HasSystem bool `json:"hasSystem"`
 	Location
 }
 
@@ -21,18 +28,103 @@ func (t *Doctype) Kind() string {
 
 type StartTag struct {
 	// Name must contain only letters, digits, hyphens, and colons, although it must start with a letter.
-	Name          string
-	Attributes    map[string]Attribute
-	IsSelfClosing bool
+	Name string `json:"name"`
+	// Atom is the interned lowercase Name, 0 for names outside the atom table.
+	Atom          atom.Atom            `json:"-"`
+	Attributes    map[string]Attribute `json:"attributes"`
+	IsSelfClosing bool                 `json:"isSelfClosing"`
+	// Expressions in place of attributes, only recognized when expressions are enabled on the tokenizer.
+	Expressions []*Expression `json:"expressions,omitempty"`
 	Location
+	lazy *lazyAttributes
 }
 
 func (t *StartTag) Kind() string {
 	return "START_TAG"
 }
 
+type lazyAttributes struct {
+	tokenizer Tokenizer
+	end       int
+}
+
+// ParseAttributes parses attributes deferred by a tokenizer with lazy attributes enabled, doing nothing
+// for tags whose attributes are already parsed.
+func (t *StartTag) ParseAttributes() error {
+	if t.lazy == nil {
+		return nil
+	}
+
+	var raw RawToken
+	if !t.lazy.tokenizer.attributes(&raw) {
+		return &Illegal{Reason: raw.Reason, Location: raw.Location, Code: raw.Code}
+	}
+
+	t.setAttributes(&raw)
+	t.lazy = nil
+	return nil
+}
+
+// RawAttributes returns the source of attributes which have not been parsed yet.
+func (t *StartTag) RawAttributes() string {
+	if t.lazy == nil {
+		return ""
+	}
+	return string(t.lazy.tokenizer.template[t.lazy.tokenizer.i:t.lazy.end])
+}
+
+// GetAttribute looks up an attribute by its case-insensitive name. Attributes of lazy tags are only found once
+// they are parsed.
+func (t *StartTag) GetAttribute(name string) (Attribute, bool) {
+	if attribute, ok := t.Attributes[name]; ok {
+		return attribute, true
+	}
+	for key, attribute := range t.Attributes {
+		if strings.EqualFold(key, name) {
+			return attribute, true
+		}
+	}
+	return Attribute{}, false
+}
+
+func (t *StartTag) HasAttribute(name string) bool {
+	_, ok := t.GetAttribute(name)
+	return ok
+}
+
+// ID returns the value of the id attribute, empty when there is none.
+func (t *StartTag) ID() string {
+	id, _ := t.GetAttribute("id")
+	return id.Value
+}
+
+// Classes returns the class names in the class attribute, see ParseClassList.
+func (t *StartTag) Classes() []string {
+	class, _ := t.GetAttribute("class")
+	return ParseClassList(class.Value)
+}
+
+// ParseClassList parses a set of space-separated tokens, such as the class attribute, returning the tokens in order
+// of their first occurrence without duplicates.
+// https://dom.spec.whatwg.org/#concept-ordered-set-parser
+func ParseClassList(value string) []string {
+	var tokens []string
+	for _, token := range strings.FieldsFunc(value, isWhitespace) {
+		if !slices.Contains(tokens, token) {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
+
+// JoinClassList serializes a set of tokens as an attribute value, the inverse of ParseClassList.
+func JoinClassList(tokens []string) string {
+	return strings.Join(tokens, " ")
+}
+
 type EndTag struct {
-	Name string
+	Name string    `json:"name"`
+	Atom atom.Atom `json:"-"`
 	Location
 }
 
@@ -41,7 +133,7 @@ func (t *EndTag) Kind() string {
 }
 
 type Text struct {
-	Value string
+	Value string `json:"value"`
 	Location
 }
 
@@ -50,15 +142,50 @@ func (t *Text) Kind() string {
 }
 
 type Attribute struct {
-	Name          string
-	Value         string
-	NameLocation  Location
-	ValueLocation Location
+	Name  string    `json:"name"`
+	Atom  atom.Atom `json:"-"`
+	Value string    `json:"value"`
+	// Quote is the quote around Value, 0 for expression values and attributes without a value.
+	Quote rune `json:"quote,omitempty"`
+	// NameLocation and NameEnd delimit the name, NameEnd being exclusive.
+	NameLocation Location `json:"nameLocation"`
+	NameEnd      Location `json:"nameEnd"`
+	// EqualsLocation is the location of `=`, zero for attributes without a value.
+	EqualsLocation Location `json:"equalsLocation"`
+	// ValueLocation and ValueEnd delimit the value including its quotes, ValueEnd being exclusive. The opening
+	// quote is at ValueLocation and the closing one right before ValueEnd.
+	ValueLocation Location `json:"valueLocation"`
+	ValueEnd      Location `json:"valueEnd"`
+	// Expressions embedded in Value, only recognized when expressions are enabled on the tokenizer.
+	Expressions []*Expression `json:"expressions,omitempty"`
+}
+
+// ValueRange returns the range of the value without its quotes, so that the value can be replaced on its own.
+func (a Attribute) ValueRange() Range {
+	if a.Quote == 0 {
+		return Range{a.ValueLocation, a.ValueEnd}
+	}
+	start, end := a.ValueLocation, a.ValueEnd
+	start.Column, start.Cursor = start.Column+1, start.Cursor+1
+	end.Column, end.Cursor = end.Column-1, end.Cursor-1
+	return Range{start, end}
+}
+
+type Expression struct {
+	// Value is the source between the delimiters, including any surrounding whitespace.
+	Value string `json:"value"`
+	Location
+}
+
+func (t *Expression) Kind() string {
+	return "EXPRESSION"
 }
 
 type Illegal struct {
-	Reason string
+	Reason string `json:"reason"`
 	Location
+	// Code identifies the kind of error, one of the Code constants, empty for illegal tokens made elsewhere.
+	Code string `json:"code,omitempty"`
 }
 
 func (t *Illegal) Kind() string {
@@ -0,0 +1,82 @@
+package html
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// TraceEvent is a step of the tokenizer reported to the trace set with SetTrace.
+type TraceEvent struct {
+	// Message describes the step, e.g. `START_TAG div [id class]` or `raw text until </script>`.
+	Message string
+	// Range is the input consumed by the step, which for illegal tokens extends past where the token became illegal.
+	Range
+}
+
+// SetTrace makes the tokenizer report every token it reads, along with the input it consumed and the raw text
+// state it enters and leaves, to debug why an input gives unexpected tokens. A nil trace turns tracing off. The trace
+// is only called from the goroutine reading tokens: Parallel traces the tokens it returns once they are in order, and
+// a Stream traces tokens once they are complete.
+func (t *Tokenizer) SetTrace(trace func(TraceEvent)) {
+	t.trace = trace
+}
+
+// TraceTo returns a trace writing each event to w on its own line, as `start-end message` with lines and columns.
+// Write errors are ignored.
+func TraceTo(w io.Writer) func(TraceEvent) {
+	return func(event TraceEvent) {
+		fmt.Fprintf(w, "%d:%d-%d:%d %s\n", event.Start.Line, event.Start.Column, event.End.Line, event.End.Column, event.Message)
+	}
+}
+
+// traceToken reports a token read from the start location in the given raw text state, the tokenizer being in the
+// state after it.
+func (t *Tokenizer) traceToken(token Token, start Location, rawText []rune) {
+	var message strings.Builder
+	message.WriteString(token.Kind())
+
+	switch token := token.(type) {
+	case *Doctype:
+		if token.HasSystem {
+			message.WriteString(" with legacy compat system identifier")
+		}
+	case *StartTag:
+		message.WriteString(" " + token.Name)
+		if len(token.Attributes) > 0 {
+			attributes := slices.SortedFunc(maps.Values(token.Attributes), func(a, b Attribute) int {
+				return a.NameLocation.Cursor - b.NameLocation.Cursor
+			})
+			names := make([]string, len(attributes))
+			for i, attribute := range attributes {
+				names[i] = attribute.Name
+			}
+			message.WriteString(" [" + strings.Join(names, " ") + "]")
+		}
+		if len(token.Expressions) > 0 {
+			fmt.Fprintf(&message, " with %d expressions", len(token.Expressions))
+		}
+		if token.IsSelfClosing {
+			message.WriteString(" self-closing")
+		}
+	case *EndTag:
+		message.WriteString(" " + token.Name)
+	case *Text:
+		if len(rawText) > 0 {
+			message.WriteString(" of <" + string(rawText) + ">")
+		}
+		message.WriteString(" " + strconv.Quote(token.Value))
+	case *Expression:
+		message.WriteString(" " + strconv.Quote(token.Value))
+	case *Illegal:
+		fmt.Fprintf(&message, " %s at %d:%d: %s", token.Code, token.Line, token.Column, token.Reason)
+	}
+	t.trace(TraceEvent{message.String(), Range{start, t.location()}})
+
+	if len(t.rawText) > 0 && len(rawText) == 0 {
+		t.trace(TraceEvent{"raw text until </" + string(t.rawText) + ">", Range{t.location(), t.location()}})
+	}
+}
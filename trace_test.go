@@ -0,0 +1,69 @@
+package html
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTrace(t *testing.T) {
+	var b strings.Builder
+	tokenizer := NewTokenizer("<p class=\"a\">Hi {{ name }}</p>\n<script>a < b</script><div id=x>")
+	tokenizer.EnableExpressions(DefaultDelimiters)
+	tokenizer.SetTrace(TraceTo(&b))
+	for range tokenizer.All() {
+	}
+
+	expected := `1:1-1:14 START_TAG p [class]
+1:14-1:17 TEXT "Hi "
+1:17-1:27 EXPRESSION " name "
+1:27-1:31 END_TAG p
+1:31-2:1 TEXT "\n"
+2:1-2:9 START_TAG script
+2:9-2:9 raw text until </script>
+2:9-2:14 TEXT of <script> "a < b"
+2:14-2:23 END_TAG script
+2:23-2:31 ILLEGAL unquoted-attribute-value at 2:31: expected quotes in attribute definition
+2:31-2:33 TEXT "x>"
+2:33-2:33 EOF
+`
+	if b.String() != expected {
+		t.Errorf("got\n%s\nexpected\n%s", b.String(), expected)
+	}
+}
+
+func TestParallelTrace(t *testing.T) {
+	template := strings.Repeat("<div class=\"a\">text <b>bold</b></div>\n<script>a < b</script>", 200)
+
+	var expected strings.Builder
+	sequential := NewTokenizer(template)
+	sequential.SetTrace(TraceTo(&expected))
+	for range sequential.All() {
+	}
+
+	var b strings.Builder
+	tokenizer := NewTokenizer(template)
+	tokenizer.SetTrace(TraceTo(&b))
+	tokenizer.Parallel(8)
+
+	if b.String() != expected.String() {
+		t.Errorf("parallel trace differs from sequential, got %d bytes, expected %d", b.Len(), expected.Len())
+	}
+}
+
+func TestStreamTrace(t *testing.T) {
+	var b strings.Builder
+	tokenizer := NewTokenizer("")
+	tokenizer.SetTrace(TraceTo(&b))
+	stream := tokenizer.Stream(func(Token) error { return nil })
+	for _, chunk := range []string{"<di", "v cla", "ss=\"a\">te", "xt</div>"} {
+		stream.Write([]byte(chunk))
+	}
+
+	expected := `1:1-1:16 START_TAG div [class]
+1:16-1:20 TEXT "text"
+1:20-1:26 END_TAG div
+`
+	if b.String() != expected {
+		t.Errorf("got\n%s\nexpected\n%s", b.String(), expected)
+	}
+}
@@ -0,0 +1,81 @@
+package html
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ErrMissingAttribute is wrapped by the errors of typed attribute accessors when the attribute is absent.
+var ErrMissingAttribute = errors.New("missing attribute")
+
+// attributeError wraps err with the attribute and tag it concerns.
+func (t *StartTag) attributeError(name string, err error) error {
+	return fmt.Errorf("attribute %q of <%s>: %w", name, strings.ToLower(t.Name), err)
+}
+
+// AttrInt reads an attribute following the HTML rules for parsing integers: leading whitespace and a sign are
+// allowed, and anything after the digits is ignored, so `" 42px"` reads as 42.
+// https://html.spec.whatwg.org/multipage/common-microsyntaxes.html#rules-for-parsing-integers
+func (t *StartTag) AttrInt(name string) (int, error) {
+	attribute, ok := t.GetAttribute(name)
+	if !ok {
+		return 0, t.attributeError(name, ErrMissingAttribute)
+	}
+
+	value := strings.TrimLeftFunc(attribute.Value, isWhitespace)
+	end := 0
+	if strings.HasPrefix(value, "-") || strings.HasPrefix(value, "+") {
+		end++
+	}
+	digits := end
+	for end < len(value) && isDigit(rune(value[end])) {
+		end++
+	}
+	if end == digits {
+		return 0, t.attributeError(name, fmt.Errorf("%q is not an integer", attribute.Value))
+	}
+
+	n, err := strconv.Atoi(strings.TrimPrefix(value[:end], "+"))
+	if err != nil {
+		return 0, t.attributeError(name, fmt.Errorf("%q is out of range", attribute.Value))
+	}
+	return n, nil
+}
+
+// AttrBool reads a boolean attribute, which is true when present whatever its value, as with `disabled="false"`.
+func (t *StartTag) AttrBool(name string) bool {
+	return t.HasAttribute(name)
+}
+
+// AttrURL reads an attribute as a URL resolved against base, which may be nil, see ResolveURL.
+func (t *StartTag) AttrURL(name string, base *url.URL) (*url.URL, error) {
+	attribute, ok := t.GetAttribute(name)
+	if !ok {
+		return nil, t.attributeError(name, ErrMissingAttribute)
+	}
+
+	resolved, err := ResolveURL(base, attribute.Value)
+	if err != nil {
+		return nil, t.attributeError(name, err)
+	}
+	return resolved, nil
+}
+
+// AttrEnum reads an enumerated attribute, matching its value against the allowed keywords ignoring ASCII case and
+// returning the matched keyword as given in allowed.
+func (t *StartTag) AttrEnum(name string, allowed ...string) (string, error) {
+	attribute, ok := t.GetAttribute(name)
+	if !ok {
+		return "", t.attributeError(name, ErrMissingAttribute)
+	}
+
+	for _, keyword := range allowed {
+		if equalFold([]rune(attribute.Value), []rune(keyword)) {
+			return keyword, nil
+		}
+	}
+	return "", t.attributeError(name, fmt.Errorf("%q is not one of %s", attribute.Value, strings.Join(allowed, ", ")))
+}
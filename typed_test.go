@@ -0,0 +1,48 @@
+package html
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+)
+
+func TestTypedAttributes(t *testing.T) {
+	var tag *StartTag
+	for token := range Tokenize(`<input width=" +42px" height="px" max="99999999999999999999" disabled="false" src=" /img.png " type="CHECKBOX">`) {
+		tag = token.(*StartTag)
+	}
+
+	if width, err := tag.AttrInt("width"); width != 42 || err != nil {
+		t.Errorf("got %d, %v", width, err)
+	}
+	if _, err := tag.AttrInt("height"); err == nil || err.Error() != `attribute "height" of <input>: "px" is not an integer` {
+		t.Errorf("unexpected error %v", err)
+	}
+	if _, err := tag.AttrInt("max"); err == nil {
+		t.Error("expected an out of range error")
+	}
+	if _, err := tag.AttrInt("size"); !errors.Is(err, ErrMissingAttribute) {
+		t.Errorf("expected a missing attribute error, got %v", err)
+	}
+
+	if !tag.AttrBool("disabled") || tag.AttrBool("checked") {
+		t.Error("unexpected boolean attributes")
+	}
+
+	base, _ := url.Parse("https://example.com/a/")
+	if src, err := tag.AttrURL("src", base); err != nil || src.String() != "https://example.com/img.png" {
+		t.Errorf("got %v, %v", src, err)
+	}
+
+	if kind, err := tag.AttrEnum("type", "text", "checkbox", "radio"); kind != "checkbox" || err != nil {
+		t.Errorf("got %q, %v", kind, err)
+	}
+	if _, err := tag.AttrEnum("type", "text"); err == nil || err.Error() != `attribute "type" of <input>: "CHECKBOX" is not one of text` {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	tag.Attributes["type"] = Attribute{Name: "type", Value: "\u212Aeygen"}
+	if kind, err := tag.AttrEnum("type", "keygen"); err == nil {
+		t.Errorf("got %q, expected the Kelvin sign not to match k", kind)
+	}
+}